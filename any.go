@@ -0,0 +1,181 @@
+package zaplint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// anyBasics are the basic types zap.Any has a case for, with the constructors of the values,
+// pointers and slices of that type.
+var anyBasics = []struct {
+	kind                     types.BasicKind
+	value, pointer, elements string
+}{
+	{types.Bool, "Bool", "Boolp", "Bools"},
+	{types.Complex128, "Complex128", "Complex128p", "Complex128s"},
+	{types.Complex64, "Complex64", "Complex64p", "Complex64s"},
+	{types.Float64, "Float64", "Float64p", "Float64s"},
+	{types.Float32, "Float32", "Float32p", "Float32s"},
+	{types.Int, "Int", "Intp", "Ints"},
+	{types.Int64, "Int64", "Int64p", "Int64s"},
+	{types.Int32, "Int32", "Int32p", "Int32s"},
+	{types.Int16, "Int16", "Int16p", "Int16s"},
+	{types.Int8, "Int8", "Int8p", "Int8s"},
+	{types.String, "String", "Stringp", "Strings"},
+	{types.Uint, "Uint", "Uintp", "Uints"},
+	{types.Uint64, "Uint64", "Uint64p", "Uint64s"},
+	{types.Uint32, "Uint32", "Uint32p", "Uint32s"},
+	{types.Uint16, "Uint16", "Uint16p", "Uint16s"},
+	{types.Uint8, "Uint8", "Uint8p", "Binary"},
+	{types.Uintptr, "Uintptr", "Uintptrp", "Uintptrs"},
+}
+
+// anyCase is a case of the type switch of zap.Any.
+type anyCase struct {
+	typ   types.Type
+	ctor  string // Name of the zap constructor the value is passed to.
+	iface bool   // Whether typ is an interface, matched by the values implementing it.
+}
+
+// anyCases returns the cases of the type switch of zap.Any, in order, or nil if zap is not imported.
+func (r *resolver) anyCases() []anyCase {
+	if r.anySwitch != nil || !r.importsZap() {
+		return r.anySwitch
+	}
+	var zap, zapcore, timePkg, fmtPkg *types.Package
+	for pkg, path := range r.zapPkgs {
+		switch path {
+		case zapModule:
+			zap = pkg
+		case zapModule + "/zapcore":
+			zapcore = pkg
+		}
+	}
+	if zap == nil || zapcore == nil {
+		return nil
+	}
+	for _, imp := range zap.Imports() {
+		switch imp.Path() {
+		case "time":
+			timePkg = imp
+		case "fmt":
+			fmtPkg = imp
+		}
+	}
+	if timePkg == nil || fmtPkg == nil {
+		return nil
+	}
+	lookup := func(pkg *types.Package, name string) types.Type {
+		return pkg.Scope().Lookup(name).Type()
+	}
+
+	cases := []anyCase{
+		{typ: lookup(zapcore, "ObjectMarshaler"), ctor: "Object", iface: true},
+		{typ: lookup(zapcore, "ArrayMarshaler"), ctor: "Array", iface: true},
+		{typ: types.NewSlice(lookup(zap, "Field")), ctor: "Dict"},
+	}
+	add := func(t types.Type, value, pointer, elements string) {
+		cases = append(cases,
+			anyCase{typ: t, ctor: value},
+			anyCase{typ: types.NewPointer(t), ctor: pointer},
+			anyCase{typ: types.NewSlice(t), ctor: elements},
+		)
+	}
+	for _, basic := range anyBasics {
+		add(types.Typ[basic.kind], basic.value, basic.pointer, basic.elements)
+	}
+	add(lookup(timePkg, "Time"), "Time", "Timep", "Times")
+	add(lookup(timePkg, "Duration"), "Duration", "Durationp", "Durations")
+	errorType := types.Universe.Lookup("error").Type()
+	cases = append(cases,
+		anyCase{typ: errorType, ctor: "NamedError", iface: true},
+		anyCase{typ: types.NewSlice(errorType), ctor: "Errors"},
+		anyCase{typ: lookup(fmtPkg, "Stringer"), ctor: "Stringer", iface: true},
+	)
+	r.anySwitch = cases
+	return cases
+}
+
+// anyConstructor returns the constructor zap.Any passes a value of type t to,
+// or "" if it is zap.Reflect or cannot be known statically.
+func (r *resolver) anyConstructor(t types.Type) string {
+	isIface := types.IsInterface(t)
+	for _, c := range r.anyCases() {
+		switch {
+		case c.iface && types.Implements(t, c.typ.Underlying().(*types.Interface)):
+			return c.ctor
+		case isIface:
+			// The case matched depends on the dynamic type of the value, unless all
+			// the values implement the first cases.
+			return ""
+		case !c.iface && types.Identical(t, c.typ):
+			return c.ctor
+		}
+	}
+	return ""
+}
+
+// elementsConstructor returns the generic constructor of the slices of type t, zap.Objects or zap.Stringers,
+// if zap.Any encodes them with reflection but their elements are ObjectMarshalers or Stringers.
+func (r *resolver) elementsConstructor(t types.Type) string {
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return ""
+	}
+	for _, c := range r.anyCases() {
+		if c.iface && (c.ctor == "Object" || c.ctor == "Stringer") && types.Implements(slice.Elem(), c.typ.Underlying().(*types.Interface)) {
+			return c.ctor + "s"
+		}
+	}
+	return ""
+}
+
+// qualifier qualifies the types of other packages by their name, as in the code.
+func qualifier(pkg *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+		return other.Name()
+	}
+}
+
+// checkAny reports calls to zap.Any and zap.Reflect with values for which zap has a specific constructor,
+// which is explicit about the type of the field and avoids the type switch or the reflection.
+func checkAny(pass *analysis.Pass, r *resolver, call *ast.CallExpr, name string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 2 {
+		return
+	}
+	t := pass.TypesInfo.TypeOf(call.Args[1])
+	if t == nil {
+		return
+	}
+	if basic, ok := t.(*types.Basic); ok && basic.Info()&types.IsUntyped != 0 {
+		// e.g. nil.
+		return
+	}
+	ctor := r.anyConstructor(t)
+	if ctor == "" {
+		ctor = r.elementsConstructor(t)
+	}
+	if ctor == "" {
+		return
+	}
+	edits := []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(ctor)}}
+	if ctor == "Dict" {
+		// zap.Dict takes the fields as variadic arguments.
+		edits = append(edits, analysis.TextEdit{Pos: call.Args[1].End(), End: call.Args[1].End(), NewText: []byte("...")})
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     sel.Sel.Pos(),
+		Message: fmt.Sprintf("zap.%s should not be used for %s values, use zap.%s instead", name, types.TypeString(t, qualifier(pass.Pkg)), ctor),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Use zap.%s", ctor),
+			TextEdits: edits,
+		}},
+	})
+}
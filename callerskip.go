@@ -0,0 +1,122 @@
+package zaplint
+
+import (
+	"go/constant"
+	"go/token"
+	"maps"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkCallerSkip reports wrappers forwarding to a logger whose zap.AddCallerSkip total
+// does not match the number of wrappers between the caller and the logger.
+// Otherwise, the caller annotation points at a wrapper instead of the real call site.
+func checkCallerSkip(pass *analysis.Pass, opts *Options, r *resolver) {
+	result := pass.ResultOf[opts.wrappers].(*wrappers)
+	if len(result.local) == 0 {
+		return
+	}
+
+	// A wrapper only called by other wrappers needs to skip them too.
+	expected := make(map[*wrapper]int)
+	for _, w := range result.local {
+		depth := w.fact.Depth
+		for inner := w; inner != nil; inner = inner.next(result) {
+			expected[inner] = max(expected[inner], depth)
+		}
+	}
+
+	ssainfo := r.ssaPackage(pass)
+	tracer := newSkipTracer(r, ssainfo)
+	for _, w := range result.local {
+		if info, ok := r.lookup(w.callee); !ok || info.IsWrapper {
+			// Forwards to another wrapper, which is checked instead.
+			continue
+		}
+		call := findCall(ssainfo.Pkg.Prog.FuncValue(w.fn), w.call.Lparen)
+		if call == nil || call.Common().IsInvoke() || len(call.Common().Args) == 0 {
+			continue
+		}
+		for skip := range tracer.trace(call.Common().Args[0]) {
+			if skip != expected[w] {
+				pass.Reportf(w.call.Pos(), "wrapper %s should use a logger built with zap.AddCallerSkip(%d), found %d", w.fn.Name(), expected[w], skip)
+				break
+			}
+		}
+	}
+}
+
+// next returns the local wrapper called by w, if any.
+func (w *wrapper) next(result *wrappers) *wrapper {
+	for _, inner := range result.local {
+		if inner.fn == w.callee {
+			return inner
+		}
+	}
+	return nil
+}
+
+// findCall returns the call instruction of fn at the given position.
+func findCall(fn *ssa.Function, lparen token.Pos) *ssa.Call {
+	if fn == nil {
+		return nil
+	}
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if call, ok := instr.(*ssa.Call); ok && call.Pos() == lparen {
+				return call
+			}
+		}
+	}
+	return nil
+}
+
+// skipHandler computes the set of caller skips with which loggers may have been built,
+// from the total of the zap.AddCallerSkip options applied. Loggers whose construction cannot be traced are ignored.
+type skipHandler struct {
+	resolver *resolver
+}
+
+func newSkipTracer(r *resolver, ssainfo *buildssa.SSA) *loggerTracer[map[int]bool] {
+	return newLoggerTracer[map[int]bool](r, ssainfo, skipHandler{resolver: r})
+}
+
+func (skipHandler) core(ssa.Value) map[int]bool   { return map[int]bool{0: true} }
+func (skipHandler) config(ssa.Value) map[int]bool { return map[int]bool{0: true} }
+func (skipHandler) preset(string) map[int]bool    { return map[int]bool{0: true} }
+func (skipHandler) unknown() map[int]bool         { return map[int]bool{} }
+func (skipHandler) visiting() map[int]bool        { return map[int]bool{} }
+
+func (h skipHandler) options(v ssa.Value, base map[int]bool) map[int]bool {
+	n := h.callerSkipOptions(v)
+	skips := make(map[int]bool, len(base))
+	for skip := range base {
+		skips[skip+n] = true
+	}
+	return skips
+}
+
+func (skipHandler) join(values []map[int]bool) map[int]bool {
+	skips := make(map[int]bool)
+	for _, value := range values {
+		maps.Copy(skips, value)
+	}
+	return skips
+}
+
+// callerSkipOptions returns the total of the zap.AddCallerSkip options passed as the variadic argument v.
+func (h skipHandler) callerSkipOptions(v ssa.Value) int {
+	total := 0
+	for _, opt := range variadicArgs(v) {
+		call, ok := opt.(*ssa.Call)
+		if !ok || h.resolver.ssaCalleeName(call.Common()) != zapModule+".AddCallerSkip" {
+			continue
+		}
+		if c, ok := call.Call.Args[0].(*ssa.Const); ok && c.Value != nil && c.Value.Kind() == constant.Int {
+			total += int(c.Int64())
+		}
+	}
+	return total
+}
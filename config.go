@@ -0,0 +1,432 @@
+package zaplint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Types of the configurations checked by Options.ConfigValidation.
+const (
+	zapConfig      = "zap.Config"
+	samplingConfig = "zap.SamplingConfig"
+	encoderConfig  = "zapcore.EncoderConfig"
+)
+
+// configType returns the name of the zap configuration type of t (e.g. "zap.Config"), or "" if t is none.
+// Pointers to configurations are configurations.
+func (r *resolver) configType(t types.Type) string {
+	if t == nil {
+		return ""
+	}
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return ""
+	}
+	var name string
+	switch r.zapPkgs[named.Obj().Pkg()] {
+	case zapModule:
+		name = "zap." + named.Obj().Name()
+	case zapModule + "/zapcore":
+		name = "zapcore." + named.Obj().Name()
+	}
+	switch name {
+	case zapConfig, samplingConfig, encoderConfig:
+		return name
+	}
+	return ""
+}
+
+// registrationsFact is exported for packages registering sinks or encoders with constant names,
+// so that the configurations of the packages importing them may use them.
+type registrationsFact struct {
+	Sinks    []string // Schemes registered with zap.RegisterSink.
+	Encoders []string // Names registered with zap.RegisterEncoder.
+}
+
+func (*registrationsFact) AFact() {}
+
+func (f *registrationsFact) String() string {
+	return fmt.Sprintf("zap registrations(sinks: %v, encoders: %v)", f.Sinks, f.Encoders)
+}
+
+// registrations are the sinks and encoders available to the analyzed package: the ones zap registers,
+// and the ones registered by the package and its dependencies.
+// It is the result of the registrations analyzer.
+type registrations struct {
+	sinks    []string
+	encoders []string
+}
+
+// newRegistrationsAnalyzer creates the analyzer collecting the sinks and encoders registered by the program
+// (Options.ConfigValidation).
+func newRegistrationsAnalyzer(opts *Options) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:       "zaplintregistrations",
+		Doc:        "collect the sinks and encoders registered with go.uber.org/zap",
+		Requires:   []*analysis.Analyzer{opts.wrappers},
+		FactTypes:  []analysis.Fact{new(registrationsFact)},
+		ResultType: reflect.TypeFor[*registrations](),
+		Run: func(pass *analysis.Pass) (any, error) {
+			if !opts.ConfigValidation {
+				return (*registrations)(nil), nil
+			}
+			return collectRegistrations(pass, opts), nil
+		},
+	}
+}
+
+// collectRegistrations exports the sinks and encoders registered by the package as a fact,
+// and returns them with the ones of its dependencies and of zap.
+func collectRegistrations(pass *analysis.Pass, opts *Options) *registrations {
+	result := &registrations{sinks: []string{"file"}, encoders: []string{"console", "json"}}
+	for _, fact := range pass.AllPackageFacts() {
+		f := fact.Fact.(*registrationsFact)
+		result.sinks = append(result.sinks, f.Sinks...)
+		result.encoders = append(result.encoders, f.Encoders...)
+	}
+	r := pass.ResultOf[opts.wrappers].(*wrappers).resolver
+	if !r.importsZap() || opts.isZapPkg(pass.Pkg.Path()) {
+		return result
+	}
+	fact := new(registrationsFact)
+	inspector := r.inspector(pass)
+	nodeFilter := []ast.Node{(*ast.CallExpr)(nil)}
+	inspector.Preorder(nodeFilter, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		fn := r.callee(pass.TypesInfo, call)
+		if fn == nil || len(call.Args) != 2 || (r.name(fn) != zapModule+".RegisterSink" && r.name(fn) != zapModule+".RegisterEncoder") {
+			return
+		}
+		name, ok := constString(pass.TypesInfo, call.Args[0])
+		if !ok {
+			return
+		}
+		if fn.Name() == "RegisterSink" {
+			fact.Sinks = append(fact.Sinks, name)
+		} else {
+			fact.Encoders = append(fact.Encoders, name)
+		}
+	})
+	if len(fact.Sinks) > 0 || len(fact.Encoders) > 0 {
+		pass.ExportPackageFact(fact)
+		result.sinks = append(result.sinks, fact.Sinks...)
+		result.encoders = append(result.encoders, fact.Encoders...)
+	}
+	return result
+}
+
+// constString returns the value of expr if it is a constant string.
+func constString(info *types.Info, expr ast.Expr) (string, bool) {
+	if v := info.Types[expr].Value; v != nil && v.Kind() == constant.String {
+		return constant.StringVal(v), true
+	}
+	return "", false
+}
+
+// configTarget is a variable, or a field of it, holding a configuration, e.g. cfg.Sampling.
+type configTarget struct {
+	v    *types.Var
+	path string // Dotted path of the field in the variable, e.g. "Sampling", or "" for the variable itself.
+}
+
+// configChecker checks the zap.Config, zap.SamplingConfig and zapcore.EncoderConfig composite literals
+// and field assignments against the values zap accepts, so that misconfigurations are found before runtime.
+// The fields assigned to a literal stored in a variable (e.g. s := &zap.SamplingConfig{}; s.Initial = 100)
+// count as fields of the literal, so the literals are checked once all the nodes are visited.
+type configChecker struct {
+	pass     *analysis.Pass
+	r        *resolver
+	regs     *registrations
+	lits     []*ast.CompositeLit
+	consoles []*ast.CompositeLit // Encoder configurations passed to zapcore.NewConsoleEncoder.
+	targets  map[*ast.CompositeLit]configTarget
+	assigned map[configTarget]map[string]ast.Expr
+}
+
+func newConfigChecker(pass *analysis.Pass, opts *Options, r *resolver) *configChecker {
+	return &configChecker{
+		pass:     pass,
+		r:        r,
+		regs:     pass.ResultOf[opts.registrations].(*registrations),
+		targets:  make(map[*ast.CompositeLit]configTarget),
+		assigned: make(map[configTarget]map[string]ast.Expr),
+	}
+}
+
+// store records that the configuration literal value, if it is one, is stored in lhs.
+func (c *configChecker) store(lhs, value ast.Expr) {
+	if lit := configLiteral(value); lit != nil {
+		if target, ok := configTargetOf(c.pass.TypesInfo, lhs); ok {
+			c.targets[lit] = target
+		}
+	}
+}
+
+// visit checks the node of file, and records the configuration literals and the fields assigned to them.
+func (c *configChecker) visit(file *ast.File, node ast.Node) {
+	pass, r := c.pass, c.r
+	switch node := node.(type) {
+	case *ast.CompositeLit:
+		typ := r.configType(pass.TypesInfo.TypeOf(node))
+		if typ == "" {
+			return
+		}
+		c.lits = append(c.lits, node)
+		target, stored := c.targets[node]
+		for _, elt := range node.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if id, ok := kv.Key.(*ast.Ident); ok {
+					checkConfigField(pass, c.regs, file, typ, id.Name, kv.Value)
+					if lit := configLiteral(kv.Value); lit != nil && stored {
+						// Nested configurations, visited next, are stored in the field.
+						c.targets[lit] = configTarget{target.v, joinPath(target.path, id.Name)}
+					}
+				}
+			}
+		}
+	case *ast.AssignStmt:
+		if len(node.Lhs) != len(node.Rhs) {
+			return
+		}
+		for i, lhs := range node.Lhs {
+			c.store(lhs, node.Rhs[i])
+			sel, ok := astutil.Unparen(lhs).(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			if selection, ok := pass.TypesInfo.Selections[sel]; ok && selection.Kind() == types.FieldVal {
+				if typ := r.configType(pass.TypesInfo.TypeOf(sel.X)); typ != "" {
+					checkConfigField(pass, c.regs, file, typ, sel.Sel.Name, node.Rhs[i])
+					if target, ok := configTargetOf(pass.TypesInfo, sel.X); ok {
+						if c.assigned[target] == nil {
+							c.assigned[target] = make(map[string]ast.Expr)
+						}
+						c.assigned[target][sel.Sel.Name] = node.Rhs[i]
+					}
+				}
+			}
+		}
+	case *ast.ValueSpec:
+		if len(node.Names) == len(node.Values) {
+			for i, name := range node.Names {
+				c.store(name, node.Values[i])
+			}
+		}
+	case *ast.CallExpr:
+		fn := r.callee(pass.TypesInfo, node)
+		if fn == nil || len(node.Args) != 1 || r.name(fn) != zapModule+"/zapcore.NewConsoleEncoder" {
+			return
+		}
+		if lit, ok := astutil.Unparen(node.Args[0]).(*ast.CompositeLit); ok {
+			c.consoles = append(c.consoles, lit)
+		}
+	}
+}
+
+// finish checks the configuration literals, once all the assignments are known.
+func (c *configChecker) finish() {
+	fields := make(map[*ast.CompositeLit]map[string]ast.Expr)
+	for _, lit := range c.lits {
+		fields[lit] = make(map[string]ast.Expr)
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if id, ok := kv.Key.(*ast.Ident); ok {
+					fields[lit][id.Name] = kv.Value
+				}
+			}
+		}
+		if target, ok := c.targets[lit]; ok {
+			for field, value := range c.assigned[target] {
+				if _, ok := fields[lit][field]; !ok {
+					fields[lit][field] = value
+				}
+			}
+		}
+	}
+	for _, lit := range c.lits {
+		checkConfigLiteral(c.pass, c.r, c.r.configType(c.pass.TypesInfo.TypeOf(lit)), lit, fields)
+	}
+	for _, lit := range c.consoles {
+		checkConsoleMessageKey(c.pass, lit, fields[lit])
+	}
+}
+
+// configLiteral returns the composite literal of expr, or of the address taken by expr, if any.
+func configLiteral(expr ast.Expr) *ast.CompositeLit {
+	expr = astutil.Unparen(expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = astutil.Unparen(unary.X)
+	}
+	lit, _ := expr.(*ast.CompositeLit)
+	return lit
+}
+
+// configTargetOf returns the variable, or field of a variable, set by expr (e.g. cfg.Sampling or *s).
+func configTargetOf(info *types.Info, expr ast.Expr) (configTarget, bool) {
+	var path string
+	for {
+		switch e := astutil.Unparen(expr).(type) {
+		case *ast.Ident:
+			v, ok := info.ObjectOf(e).(*types.Var)
+			return configTarget{v, path}, ok
+		case *ast.SelectorExpr:
+			if selection, ok := info.Selections[e]; !ok || selection.Kind() != types.FieldVal {
+				return configTarget{}, false
+			}
+			path = joinPath(e.Sel.Name, path)
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			return configTarget{}, false
+		}
+	}
+}
+
+// joinPath joins the dotted paths of fields.
+func joinPath(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + "." + b
+}
+
+// checkConfigField checks the value of a field of a configuration of type typ, set in file.
+func checkConfigField(pass *analysis.Pass, regs *registrations, file *ast.File, typ, field string, value ast.Expr) {
+	switch typ + "." + field {
+	case zapConfig + ".Encoding":
+		if name, ok := constString(pass.TypesInfo, value); ok && !slices.Contains(regs.encoders, name) {
+			pass.Reportf(value.Pos(), `encoding %q is not registered, use "json", "console" or register it with zap.RegisterEncoder`, name)
+		}
+	case zapConfig + ".OutputPaths", zapConfig + ".ErrorOutputPaths":
+		for _, elem := range stringElems(pass.TypesInfo, value) {
+			if path, ok := constString(pass.TypesInfo, elem); ok {
+				checkOutputPath(pass, regs, elem, path)
+			}
+		}
+	case zapConfig + ".Development":
+		if v := pass.TypesInfo.Types[value].Value; v != nil && constant.BoolVal(v) && !isTestFile(pass.Fset, file) {
+			pass.Reportf(value.Pos(), "Development should not be enabled in production code")
+		}
+	case samplingConfig + ".Initial":
+		if v := pass.TypesInfo.Types[value].Value; v != nil && constant.Sign(v) <= 0 {
+			pass.Reportf(value.Pos(), "SamplingConfig.Initial should be positive, or the first entries of each second are dropped")
+		}
+	}
+}
+
+// checkConfigLiteral checks the fields of a configuration literal of type typ depending on each other,
+// or missing from it. fields maps the configuration literals to their fields, including the ones assigned later.
+func checkConfigLiteral(pass *analysis.Pass, r *resolver, typ string, lit *ast.CompositeLit, literalFields map[*ast.CompositeLit]map[string]ast.Expr) {
+	fields := literalFields[lit]
+	set := func(field string) bool {
+		value, ok := fields[field]
+		if !ok {
+			return false
+		}
+		s, isConst := constString(pass.TypesInfo, value)
+		return !isConst || s != ""
+	}
+	switch typ {
+	case samplingConfig:
+		if _, ok := fields["Initial"]; !ok {
+			pass.Reportf(lit.Pos(), "SamplingConfig.Initial should be positive, or the first entries of each second are dropped")
+		}
+	case encoderConfig:
+		for _, pair := range [][3]string{
+			{"TimeKey", "EncodeTime", "zap.Config.Build fails"},
+			{"LevelKey", "EncodeLevel", "the level is omitted"},
+			{"CallerKey", "EncodeCaller", "the JSON encoder panics"},
+		} {
+			if set(pair[0]) && !set(pair[1]) {
+				pass.Reportf(fields[pair[0]].Pos(), "%s should be set with %s, or %s", pair[1], pair[0], pair[2])
+			}
+		}
+	case zapConfig:
+		encoding, ok := fields["Encoding"]
+		if !ok {
+			return
+		}
+		if name, _ := constString(pass.TypesInfo, encoding); name != "console" {
+			return
+		}
+		if ec, ok := astutil.Unparen(fields["EncoderConfig"]).(*ast.CompositeLit); ok && r.configType(pass.TypesInfo.TypeOf(ec)) == encoderConfig {
+			checkConsoleMessageKey(pass, ec, literalFields[ec])
+		}
+	}
+}
+
+// checkConsoleMessageKey reports the encoder configuration literal of a console encoder without a message key,
+// which omits the messages.
+func checkConsoleMessageKey(pass *analysis.Pass, lit *ast.CompositeLit, fields map[string]ast.Expr) {
+	if value, ok := fields["MessageKey"]; ok {
+		if s, isConst := constString(pass.TypesInfo, value); !isConst || s != "" {
+			return
+		}
+	}
+	pass.Reportf(lit.Pos(), "MessageKey should be set with the console encoder, or the messages are omitted")
+}
+
+// checkOutputPath reports the output path, set by expr, that zap.Open cannot open.
+func checkOutputPath(pass *analysis.Pass, regs *registrations, expr ast.Expr, path string) {
+	if filepath.IsAbs(path) {
+		return
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		pass.Reportf(expr.Pos(), "output path %q is not a valid URL", path)
+		return
+	}
+	if u.Scheme == "" {
+		u.Scheme = "file"
+	}
+	if !slices.Contains(regs.sinks, u.Scheme) {
+		pass.Reportf(expr.Pos(), "output path %q has the unregistered scheme %q, register it with zap.RegisterSink", path, u.Scheme)
+		return
+	}
+	if u.Scheme != "file" {
+		return
+	}
+	var invalid string
+	switch {
+	case u.User != nil:
+		invalid = "user and password are not allowed"
+	case u.Fragment != "":
+		invalid = "fragments are not allowed"
+	case u.RawQuery != "":
+		invalid = "query parameters are not allowed"
+	case u.Port() != "":
+		invalid = "ports are not allowed"
+	case u.Hostname() != "" && u.Hostname() != "localhost":
+		invalid = "the host should be empty or localhost"
+	default:
+		return
+	}
+	pass.Reportf(expr.Pos(), "output path %q is not a valid file URL: %s", path, invalid)
+}
+
+// stringElems returns the elements of a slice of strings built by a composite literal or appended to a slice.
+func stringElems(info *types.Info, expr ast.Expr) []ast.Expr {
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		return expr.Elts
+	case *ast.CallExpr:
+		if fn, ok := astutil.Unparen(expr.Fun).(*ast.Ident); ok && info.Uses[fn] == types.Universe.Lookup("append") && !expr.Ellipsis.IsValid() && len(expr.Args) > 0 {
+			return expr.Args[1:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package zaplint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// ConstructorPolicy restricts the zap constructors that may be called, per class of files.
+type ConstructorPolicy struct {
+	Production ConstructorRules `json:"production"` // Non-test files of non-main packages.
+	Test       ConstructorRules `json:"test"`       // Test files (_test.go).
+	Main       ConstructorRules `json:"main"`       // Non-test files of main packages.
+}
+
+// ConstructorRules lists allowed and denied constructors, e.g. "zap.NewNop" or "zap.Config.Build".
+type ConstructorRules struct {
+	Allow []string `json:"allow"` // If not empty, only these constructors may be used.
+	Deny  []string `json:"deny"`  // These constructors may not be used.
+}
+
+// allows reports whether the constructor with the given name may be used.
+func (r ConstructorRules) allows(name string) bool {
+	if slices.Contains(r.Deny, name) {
+		return false
+	}
+	return len(r.Allow) == 0 || slices.Contains(r.Allow, name)
+}
+
+// checkConstructor reports the call if it calls a constructor disallowed by the policy for the file it appears in.
+func checkConstructor(pass *analysis.Pass, opts *Options, r *resolver, file *ast.File, call *ast.CallExpr) {
+	fn := r.callee(pass.TypesInfo, call)
+	if fn == nil {
+		return
+	}
+	name, ok := r.constructorName(fn)
+	if !ok {
+		return
+	}
+	policy := &opts.ConstructorPolicy
+	class, rules := "production", policy.Production
+	switch {
+	case isTestFile(pass.Fset, file):
+		class, rules = "test", policy.Test
+	case pass.Pkg.Name() == "main":
+		class, rules = "main", policy.Main
+	}
+	if rules.allows(name) {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		Message: fmt.Sprintf("%s should not be used in %s code", name, class),
+	}
+	if class == "test" && !isConfigConstructor(name) && name != "zaptest.NewLogger" && rules.allows("zaptest.NewLogger") {
+		if t, ok := testingTBInScope(pass, call.Pos()); ok {
+			diag.Message += fmt.Sprintf(", use zaptest.NewLogger(%s) instead", t)
+			if fix, ok := zaptestFix(pass, r, file, call, t); ok {
+				diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+		}
+	}
+	pass.Report(diag)
+}
+
+// constructors maps the canonical full names of the zap functions creating loggers
+// or their configurations to their names as used in a ConstructorPolicy.
+var constructors = map[string]string{
+	zapModule + ".New":                  "zap.New",
+	zapModule + ".NewProduction":        "zap.NewProduction",
+	zapModule + ".NewDevelopment":       "zap.NewDevelopment",
+	zapModule + ".NewExample":           "zap.NewExample",
+	zapModule + ".NewNop":               "zap.NewNop",
+	zapModule + ".NewProductionConfig":  "zap.NewProductionConfig",
+	zapModule + ".NewDevelopmentConfig": "zap.NewDevelopmentConfig",
+	"(" + zapModule + ".Config).Build":  "zap.Config.Build",
+	zapModule + "/zaptest.NewLogger":    "zaptest.NewLogger",
+	zapModule + "/zaptest/observer.New": "observer.New",
+}
+
+// constructorName returns the name of fn as used in a ConstructorPolicy,
+// if fn is a zap function creating a logger or its configuration.
+func (r *resolver) constructorName(fn *types.Func) (string, bool) {
+	if r.zapPkg(fn) == "" {
+		return "", false
+	}
+	name, ok := constructors[r.name(fn)]
+	return name, ok
+}
+
+// isConfigConstructor reports whether the constructor with the given name creates a configuration,
+// which zaptest.NewLogger does not replace.
+func isConfigConstructor(name string) bool {
+	return strings.HasSuffix(name, "Config")
+}
+
+// validate returns an error if the rules list constructors unknown to the policy.
+func (r ConstructorRules) validate(name string) error {
+	known := slices.Collect(maps.Values(constructors))
+	for i, n := range r.Allow {
+		if !slices.Contains(known, n) {
+			return fmt.Errorf("zaplint: %s.Allow[%d]=%s: %w", name, i, n, errInvalidValue)
+		}
+	}
+	for i, n := range r.Deny {
+		if !slices.Contains(known, n) {
+			return fmt.Errorf("zaplint: %s.Deny[%d]=%s: %w", name, i, n, errInvalidValue)
+		}
+	}
+	return nil
+}
+
+func isTestFile(fset *token.FileSet, file *ast.File) bool {
+	return file != nil && strings.HasSuffix(fset.File(file.Pos()).Name(), "_test.go")
+}
+
+// testingTBInScope returns the name of a variable implementing testing.TB in scope at pos.
+func testingTBInScope(pass *analysis.Pass, pos token.Pos) (string, bool) {
+	for scope := pass.Pkg.Scope().Innermost(pos); scope != nil && scope != types.Universe; scope = scope.Parent() {
+		for _, name := range scope.Names() {
+			v, ok := scope.Lookup(name).(*types.Var)
+			if ok && v.Pos() < pos && isTestingTB(v.Type()) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// isTestingTB reports whether t is testing.TB or a pointer to testing.T, testing.B or testing.F.
+func isTestingTB(t types.Type) bool {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "testing" {
+		return false
+	}
+	switch named.Obj().Name() {
+	case "TB", "T", "B", "F":
+		return true
+	}
+	return false
+}
+
+// zaptestFix returns a fix replacing the call by zaptest.NewLogger(t), if the call returns a single *zap.Logger.
+func zaptestFix(pass *analysis.Pass, r *resolver, file *ast.File, call *ast.CallExpr, t string) (analysis.SuggestedFix, bool) {
+	typ := pass.TypesInfo.TypeOf(call)
+	if !r.isZapPointer(typ, "Logger") {
+		return analysis.SuggestedFix{}, false
+	}
+	// Import zaptest from the module of the logger, which may be a fork.
+	zapPkg := types.Unalias(typ.(*types.Pointer).Elem()).(*types.Named).Obj().Pkg()
+	pkgName, edits := importEdits(file, cleanVendorPath(zapPkg.Path())+"/zaptest")
+	edits = append(edits, analysis.TextEdit{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		NewText: fmt.Appendf(nil, "%s.NewLogger(%s)", pkgName, t),
+	})
+	return analysis.SuggestedFix{
+		Message:   fmt.Sprintf("Use %s.NewLogger(%s)", pkgName, t),
+		TextEdits: edits,
+	}, true
+}
+
+// importEdits returns the name under which path is imported in file,
+// along with the edits adding the import if it is missing.
+func importEdits(file *ast.File, path string) (string, []analysis.TextEdit) {
+	name := path[strings.LastIndex(path, "/")+1:]
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+			if spec.Name != nil {
+				return spec.Name.Name, nil
+			}
+			return name, nil
+		}
+	}
+	if len(file.Imports) == 0 {
+		return name, []analysis.TextEdit{{
+			Pos:     file.Name.End(),
+			End:     file.Name.End(),
+			NewText: fmt.Appendf(nil, "\n\nimport %q", path),
+		}}
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || len(gen.Specs) == 0 {
+			// Skip empty import groups, which have no spec to insert after.
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			last := gen.Specs[len(gen.Specs)-1]
+			return name, []analysis.TextEdit{{Pos: last.End(), End: last.End(), NewText: fmt.Appendf(nil, "\n\t%q", path)}}
+		}
+		return name, []analysis.TextEdit{{Pos: gen.End(), End: gen.End(), NewText: fmt.Appendf(nil, "\nimport %q", path)}}
+	}
+	return name, nil
+}
+
+// testLoggerConstructors are constructors of loggers writing outside of the test output.
+var testLoggerConstructors = []string{
+	"zap.NewNop",
+	"zap.NewExample",
+	"zap.NewProduction",
+	"zap.NewDevelopment",
+	"zap.Config.Build",
+}
+
+// checkTestLogger reports the call if it creates a logger in a test without zaptest or observer,
+// where a testing.TB is available to use zaptest.NewLogger instead.
+// The constructors denied by the policy for tests are skipped, since checkConstructor reports them.
+// stack is the path to the call.
+func checkTestLogger(pass *analysis.Pass, opts *Options, r *resolver, file *ast.File, call *ast.CallExpr, stack []ast.Node) {
+	if !isTestFile(pass.Fset, file) {
+		return
+	}
+	fn := r.callee(pass.TypesInfo, call)
+	if fn == nil || isMustArg(pass, r, call, stack) {
+		// zap.Must(constructor()) is checked as a whole.
+		return
+	}
+	// Replace zap.Must(constructor()) as a whole.
+	replaced := call
+	if r.name(fn) == zapModule+".Must" && len(call.Args) == 1 {
+		inner, ok := call.Args[0].(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		if fn = r.callee(pass.TypesInfo, inner); fn == nil {
+			return
+		}
+		call = inner
+	}
+	name, ok := r.constructorName(fn)
+	if !ok || !opts.ConstructorPolicy.Test.allows(name) || !slices.Contains(testLoggerConstructors, name) {
+		return
+	}
+	t, ok := testingTBInScope(pass, call.Pos())
+	if !ok {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		Message: fmt.Sprintf("%s should not be used in tests, use zaptest.NewLogger(%s) instead", name, t),
+	}
+	if fix, ok := zaptestFix(pass, r, file, replaced, t); ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	pass.Report(diag)
+}
+
+// isMustArg reports whether the call at the top of the stack is the argument of a zap.Must call.
+func isMustArg(pass *analysis.Pass, r *resolver, call *ast.CallExpr, stack []ast.Node) bool {
+	if len(stack) < 2 {
+		return false
+	}
+	parent, ok := stack[len(stack)-2].(*ast.CallExpr)
+	if !ok || len(parent.Args) != 1 || parent.Args[0] != call {
+		return false
+	}
+	fn := r.callee(pass.TypesInfo, parent)
+	return fn != nil && r.name(fn) == zapModule+".Must"
+}
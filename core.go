@@ -0,0 +1,241 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// checkCores checks the methods of the types of the package implementing zapcore.Core:
+// Check should add the core to the checked entry if the level is enabled as Enabled reports,
+// With should not modify the core, and Write should encode the fields of the entry.
+// Methods promoted from an embedded core are not checked.
+func checkCores(pass *analysis.Pass, r *resolver) {
+	core := r.zapcoreCore()
+	if core == nil {
+		return
+	}
+	methods := make(map[*types.Named]map[string]*ast.FuncDecl)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Recv == nil || decl.Body == nil {
+				continue
+			}
+			fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			named := recvNamed(fn)
+			if named == nil || !types.Implements(types.NewPointer(named), core) {
+				continue
+			}
+			if methods[named] == nil {
+				methods[named] = make(map[string]*ast.FuncDecl)
+			}
+			methods[named][fn.Name()] = decl
+		}
+	}
+	for _, decls := range methods {
+		if check, ok := decls["Check"]; ok {
+			checkCoreCheck(pass, check, decls["Enabled"], decls["Write"] != nil)
+		}
+		if with, ok := decls["With"]; ok {
+			checkCoreWith(pass, with)
+		}
+		if write, ok := decls["Write"]; ok {
+			checkCoreWrite(pass, write)
+		}
+	}
+}
+
+// zapcoreCore returns the zapcore.Core interface, if zapcore is imported.
+func (r *resolver) zapcoreCore() *types.Interface {
+	for pkg, path := range r.zapPkgs {
+		if path != zapModule+"/zapcore" {
+			continue
+		}
+		if tn, ok := pkg.Scope().Lookup("Core").(*types.TypeName); ok {
+			iface, _ := tn.Type().Underlying().(*types.Interface)
+			return iface
+		}
+	}
+	return nil
+}
+
+// recvNamed returns the named type of the receiver of the method fn.
+func recvNamed(fn *types.Func) *types.Named {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return nil
+	}
+	t := recv.Type()
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, _ := types.Unalias(t).(*types.Named)
+	return named
+}
+
+// recvVar returns the receiver variable of the method declared by decl, or nil if it is unnamed.
+func recvVar(info *types.Info, decl *ast.FuncDecl) *types.Var {
+	if len(decl.Recv.List) != 1 || len(decl.Recv.List[0].Names) != 1 {
+		return nil
+	}
+	v, _ := info.Defs[decl.Recv.List[0].Names[0]].(*types.Var)
+	return v
+}
+
+// recvFields returns the fields selected from the receiver by expr (e.g. [level] for c.level),
+// or false if expr is not the receiver or a field of it.
+func recvFields(info *types.Info, expr ast.Expr, recv *types.Var) ([]types.Object, bool) {
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		return nil, recv != nil && info.Uses[e] == recv
+	case *ast.SelectorExpr:
+		selection, ok := info.Selections[e]
+		if !ok || selection.Kind() != types.FieldVal {
+			return nil, false
+		}
+		fields, ok := recvFields(info, e.X, recv)
+		return append(fields, selection.Obj()), ok
+	case *ast.StarExpr:
+		return recvFields(info, e.X, recv)
+	}
+	return nil, false
+}
+
+// checkCoreCheck checks that the Check method adds the core to the checked entry with ce.AddCore,
+// unless it delegates to another core and Write is promoted, and that it checks the level like Enabled.
+func checkCoreCheck(pass *analysis.Pass, check, enabled *ast.FuncDecl, declaresWrite bool) {
+	recv := recvVar(pass.TypesInfo, check)
+	var addCore bool
+	var levelChecks [][]types.Object // Receivers of the Enabled calls, relative to the receiver of the core.
+	ast.Inspect(check.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		switch sel.Sel.Name {
+		case "AddCore":
+			addCore = true
+		case "Enabled":
+			if fields, ok := recvFields(pass.TypesInfo, sel.X, recv); ok {
+				levelChecks = append(levelChecks, fields)
+			}
+		}
+		return true
+	})
+	if !addCore {
+		if declaresWrite {
+			pass.Reportf(check.Name.Pos(), "Check should add the core to the checked entry with ce.AddCore(ent, %s), or Write is never called", recvName(check))
+		}
+		return
+	}
+	// The level is checked like Enabled if Check calls the Enabled method of the core,
+	// or the Enabled method of the level enabler Enabled delegates to.
+	consistent := slices.ContainsFunc(levelChecks, func(fields []types.Object) bool { return len(fields) == 0 })
+	if enabled == nil {
+		// Enabled is promoted from an embedded field, e.g. zapcore.LevelEnabler.
+		consistent = consistent || slices.ContainsFunc(levelChecks, func(fields []types.Object) bool {
+			return len(fields) == 1 && fields[0].(*types.Var).Embedded()
+		})
+	} else if delegate, ok := enabledDelegate(pass.TypesInfo, enabled); ok {
+		consistent = consistent || slices.ContainsFunc(levelChecks, func(fields []types.Object) bool { return slices.Equal(fields, delegate) })
+	}
+	if !consistent {
+		pass.Reportf(check.Name.Pos(), "Check should add the core only if %s.Enabled(ent.Level), so that it is consistent with Enabled", recvName(check))
+	}
+}
+
+// enabledDelegate returns the fields of the receiver Enabled delegates to, if its body is a single
+// return statement of the form return c.level.Enabled(lvl).
+func enabledDelegate(info *types.Info, enabled *ast.FuncDecl) ([]types.Object, bool) {
+	if len(enabled.Body.List) != 1 {
+		return nil, false
+	}
+	ret, ok := enabled.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil, false
+	}
+	call, ok := astutil.Unparen(ret.Results[0]).(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Enabled" {
+		return nil, false
+	}
+	return recvFields(info, sel.X, recvVar(info, enabled))
+}
+
+// recvName returns the name of the receiver of the method declared by decl, or "c" if it is unnamed.
+func recvName(decl *ast.FuncDecl) string {
+	if len(decl.Recv.List) == 1 && len(decl.Recv.List[0].Names) == 1 && decl.Recv.List[0].Names[0].Name != "_" {
+		return decl.Recv.List[0].Names[0].Name
+	}
+	return "c"
+}
+
+// checkCoreWith reports the assignments of the With method to the fields of its pointer receiver,
+// which modify the core With is called on instead of the returned one.
+func checkCoreWith(pass *analysis.Pass, with *ast.FuncDecl) {
+	recv := recvVar(pass.TypesInfo, with)
+	if recv == nil {
+		return
+	}
+	if _, ok := types.Unalias(recv.Type()).(*types.Pointer); !ok {
+		return
+	}
+	report := func(lhs ast.Expr) {
+		if fields, ok := recvFields(pass.TypesInfo, lhs, recv); ok && len(fields) > 0 {
+			pass.Reportf(lhs.Pos(), "With should not modify the core it is called on, modify a clone and return it")
+		}
+	}
+	ast.Inspect(with.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			if node.Tok != token.DEFINE {
+				for _, lhs := range node.Lhs {
+					report(lhs)
+				}
+			}
+		case *ast.IncDecStmt:
+			report(node.X)
+		}
+		return true
+	})
+}
+
+// checkCoreWrite reports the Write methods ignoring the fields of the entry.
+func checkCoreWrite(pass *analysis.Pass, write *ast.FuncDecl) {
+	params := write.Type.Params.List
+	if len(params) != 2 {
+		return
+	}
+	if len(params[1].Names) == 0 || params[1].Names[0].Name == "_" {
+		pass.Reportf(params[1].Pos(), "Write should encode the fields of the entry")
+		return
+	}
+	fields := pass.TypesInfo.Defs[params[1].Names[0]]
+	used := false
+	ast.Inspect(write.Body, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Ident); ok && fields != nil && pass.TypesInfo.Uses[id] == fields {
+			used = true
+		}
+		return !used
+	})
+	if !used {
+		pass.Reportf(params[1].Names[0].Pos(), "Write should encode the fields of the entry")
+	}
+}
@@ -0,0 +1,88 @@
+package zaplint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// checkDiscardedLogger reports the calls of the statement (*ast.ExprStmt or *ast.AssignStmt) to methods
+// deriving a new logger (With, Named, etc.) whose result is discarded. Since loggers are immutable,
+// such calls have no effect.
+func checkDiscardedLogger(pass *analysis.Pass, r *resolver, stmt ast.Stmt) {
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		call, ok := astutil.Unparen(stmt.X).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		reportDiscardedLogger(pass, r, call, func(recv string) analysis.TextEdit {
+			return analysis.TextEdit{Pos: stmt.Pos(), End: stmt.Pos(), NewText: []byte(recv + " = ")}
+		})
+	case *ast.AssignStmt:
+		if len(stmt.Lhs) != len(stmt.Rhs) {
+			return
+		}
+		for i, lhs := range stmt.Lhs {
+			if id, ok := lhs.(*ast.Ident); !ok || id.Name != "_" {
+				continue
+			}
+			call, ok := astutil.Unparen(stmt.Rhs[i]).(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			var fix func(recv string) analysis.TextEdit
+			if stmt.Tok == token.ASSIGN {
+				// In a definition (:=), the receiver would be redeclared or shadowed.
+				fix = func(recv string) analysis.TextEdit {
+					return analysis.TextEdit{Pos: lhs.Pos(), End: lhs.End(), NewText: []byte(recv)}
+				}
+			}
+			reportDiscardedLogger(pass, r, call, fix)
+		}
+	}
+}
+
+// reportDiscardedLogger reports the call if it derives a new logger.
+// If the result can be assigned back to the receiver, fix, if not nil, is used to build the suggested edit.
+func reportDiscardedLogger(pass *analysis.Pass, r *resolver, call *ast.CallExpr, fix func(recv string) analysis.TextEdit) {
+	fn := r.callee(pass.TypesInfo, call)
+	if fn == nil {
+		return
+	}
+	if info, ok := r.lookup(fn); !ok || !info.Derives {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos:     sel.Sel.Pos(),
+		Message: fmt.Sprintf("result of %s should not be discarded", fn.Name()),
+	}
+	if fix != nil && isAssignable(sel.X) && types.Identical(pass.TypesInfo.TypeOf(sel.X), pass.TypesInfo.TypeOf(call)) {
+		recv := types.ExprString(sel.X)
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Assign the result to %s", recv),
+			TextEdits: []analysis.TextEdit{fix(recv)},
+		}}
+	}
+	pass.Report(diag)
+}
+
+// isAssignable reports whether expr is a variable or a field selected from one.
+func isAssignable(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name != "_"
+	case *ast.SelectorExpr:
+		return isAssignable(expr.X)
+	default:
+		return false
+	}
+}
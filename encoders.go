@@ -0,0 +1,306 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+const (
+	objectEncoder = "(" + zapModule + "/zapcore.ObjectEncoder)."
+	arrayEncoder  = "(" + zapModule + "/zapcore.ArrayEncoder)."
+)
+
+// encoderErrorFuncs are the encoder methods returning an error.
+var encoderErrorFuncs = []string{
+	objectEncoder + "AddArray",
+	objectEncoder + "AddObject",
+	objectEncoder + "AddReflected",
+	arrayEncoder + "AppendArray",
+	arrayEncoder + "AppendObject",
+	arrayEncoder + "AppendReflected",
+}
+
+// checkMarshalerFunc checks the function (*ast.FuncDecl or *ast.FuncLit) if it implements
+// zapcore.ObjectMarshaler or zapcore.ArrayMarshaler: the keys added to the object encoder are checked
+// like the keys of logging calls, and errors returned by the encoders should not be ignored.
+// Both MarshalLogObject/MarshalLogArray methods and function literals with the same signature
+// (e.g. passed to zapcore.ObjectMarshalerFunc) are checked.
+func checkMarshalerFunc(pass *analysis.Pass, opts *Options, r *resolver, node ast.Node, reserved reservedKeys) {
+	var sig *types.Signature
+	var body *ast.BlockStmt
+	switch node := node.(type) {
+	case *ast.FuncDecl:
+		if node.Recv == nil || (node.Name.Name != "MarshalLogObject" && node.Name.Name != "MarshalLogArray") {
+			return
+		}
+		fn, ok := pass.TypesInfo.Defs[node.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		sig, body = fn.Signature(), node.Body
+	case *ast.FuncLit:
+		sig, _ = pass.TypesInfo.TypeOf(node).(*types.Signature)
+		body = node.Body
+	}
+	if sig == nil || body == nil || !r.isMarshalerSignature(sig) {
+		return
+	}
+	checkMarshaler(pass, opts, r, body, reserved)
+}
+
+// isMarshalerSignature reports whether sig is the signature of MarshalLogObject or MarshalLogArray.
+func (r *resolver) isMarshalerSignature(sig *types.Signature) bool {
+	if sig.Params().Len() != 1 || sig.Results().Len() != 1 || !isError(sig.Results().At(0).Type()) {
+		return false
+	}
+	named, ok := types.Unalias(sig.Params().At(0).Type()).(*types.Named)
+	if !ok || r.zapPkgs[named.Obj().Pkg()] != zapModule+"/zapcore" {
+		return false
+	}
+	return named.Obj().Name() == "ObjectEncoder" || named.Obj().Name() == "ArrayEncoder"
+}
+
+// checkMarshaler checks the encoder calls in the body of a marshaler.
+func checkMarshaler(pass *analysis.Pass, opts *Options, r *resolver, body *ast.BlockStmt, reserved reservedKeys) {
+	m := &marshalerChecker{pass: pass, opts: opts, r: r}
+	m.stmt(body, &encoderState{added: make(map[string]bool)})
+	checkAllKeys(pass, opts, keySets{reserved: reserved}, slices.Values(m.keys))
+}
+
+// encoderState is the state of the object encoder at a point of a marshaler, over the paths reaching it.
+// Namespaces cannot be closed: once opened, the following keys are nested in it until the end of the marshaler.
+type encoderState struct {
+	base     int             // Namespace the prefix is relative to: 0 for the object, others for namespaces with unknown paths.
+	prefix   string          // Prefix of the keys relative to the base, e.g. "details.".
+	added    map[string]bool // Keys added to the current namespace on all the paths.
+	returned bool            // Whether all the paths returned.
+}
+
+func (s *encoderState) fork() *encoderState {
+	fork := *s
+	fork.added = maps.Clone(s.added)
+	return &fork
+}
+
+// marshalerChecker checks the encoder calls of a marshaler, following the namespaces opened across its body.
+type marshalerChecker struct {
+	pass  *analysis.Pass
+	opts  *Options
+	r     *resolver
+	keys  []logKey
+	bases int // Number of namespaces with unknown paths.
+}
+
+// merge returns the state after paths in states of the control flow join.
+// Namespaces differing between the paths are replaced by a namespace with an unknown path.
+func (m *marshalerChecker) merge(states ...*encoderState) *encoderState {
+	var merged *encoderState
+	for _, s := range states {
+		switch {
+		case s.returned:
+		case merged == nil:
+			merged = s.fork()
+		case merged.base != s.base || merged.prefix != s.prefix:
+			m.bases++
+			merged.base, merged.prefix, merged.added = m.bases, "", make(map[string]bool)
+		default:
+			maps.DeleteFunc(merged.added, func(key string, _ bool) bool { return !s.added[key] })
+		}
+	}
+	if merged == nil {
+		return &encoderState{added: make(map[string]bool), returned: true}
+	}
+	return merged
+}
+
+// stmt checks the encoder calls of stmt in state s and returns the state after it.
+func (m *marshalerChecker) stmt(stmt ast.Stmt, s *encoderState) *encoderState {
+	switch stmt := stmt.(type) {
+	case *ast.BlockStmt:
+		for _, stmt := range stmt.List {
+			s = m.stmt(stmt, s)
+		}
+		return s
+	case *ast.LabeledStmt:
+		return m.stmt(stmt.Stmt, s)
+	case *ast.IfStmt:
+		m.calls(stmt.Init, s)
+		m.calls(stmt.Cond, s)
+		body := m.stmt(stmt.Body, s.fork())
+		if stmt.Else == nil {
+			return m.merge(body, s)
+		}
+		return m.merge(body, m.stmt(stmt.Else, s.fork()))
+	case *ast.ForStmt:
+		m.calls(stmt.Init, s)
+		m.calls(stmt.Cond, s)
+		body := m.stmt(stmt.Body, s.fork())
+		m.calls(stmt.Post, body)
+		// The body may not run.
+		return m.merge(s, body)
+	case *ast.RangeStmt:
+		m.calls(stmt.X, s)
+		return m.merge(s, m.stmt(stmt.Body, s.fork()))
+	case *ast.SwitchStmt:
+		m.calls(stmt.Init, s)
+		m.calls(stmt.Tag, s)
+		return m.clauses(stmt.Body, s)
+	case *ast.TypeSwitchStmt:
+		m.calls(stmt.Init, s)
+		m.calls(stmt.Assign, s)
+		return m.clauses(stmt.Body, s)
+	case *ast.SelectStmt:
+		return m.clauses(stmt.Body, s)
+	case *ast.ReturnStmt:
+		m.calls(stmt, s)
+		s.returned = true
+		return s
+	}
+	m.calls(stmt, s)
+	return s
+}
+
+// clauses checks the case clauses of a switch or select statement in state s and returns the state after it.
+func (m *marshalerChecker) clauses(body *ast.BlockStmt, s *encoderState) *encoderState {
+	var states []*encoderState
+	exhaustive := false
+	for _, clause := range body.List {
+		c := s.fork()
+		switch clause := clause.(type) {
+		case *ast.CaseClause:
+			exhaustive = exhaustive || clause.List == nil
+			for _, expr := range clause.List {
+				m.calls(expr, c)
+			}
+			for _, stmt := range clause.Body {
+				c = m.stmt(stmt, c)
+			}
+		case *ast.CommClause:
+			exhaustive = true // A select statement runs one of its clauses.
+			m.calls(clause.Comm, c)
+			for _, stmt := range clause.Body {
+				c = m.stmt(stmt, c)
+			}
+		}
+		states = append(states, c)
+	}
+	if !exhaustive {
+		states = append(states, s)
+	}
+	return m.merge(states...)
+}
+
+// calls checks the encoder calls in node, which contains no statement with control flow, in state s.
+func (m *marshalerChecker) calls(node ast.Node, s *encoderState) {
+	if node == nil {
+		return
+	}
+	var stack []ast.Node
+	ast.Inspect(node, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if _, ok := node.(*ast.FuncLit); ok {
+			// Checked separately if it is a marshaler itself.
+			return false
+		}
+		stack = append(stack, node)
+		if call, ok := node.(*ast.CallExpr); ok {
+			m.call(call, stack, s)
+		}
+		return true
+	})
+}
+
+// call checks the call at the top of the stack, if it is an encoder call, in state s.
+func (m *marshalerChecker) call(call *ast.CallExpr, stack []ast.Node, s *encoderState) {
+	pass, opts := m.pass, m.opts
+	name := encoderMethod(pass, m.r, call)
+	if name == "" {
+		return
+	}
+	if !opts.AllowIgnoredEncoderErrors && slices.Contains(encoderErrorFuncs, name) && isIgnoredResult(stack) {
+		pass.Reportf(call.Pos(), "error returned by %s should be checked", name[strings.LastIndex(name, ".")+1:])
+	}
+	if (name == objectEncoder+"AddReflected" || name == arrayEncoder+"AppendReflected") && opts.reflectionPolicy(pass.Pkg.Path()) == reflectionForbid {
+		pass.Reportf(call.Pos(), "%s encodes the value with reflection, which is forbidden in this package", name[strings.LastIndex(name, ".")+1:])
+	}
+	if !strings.HasPrefix(name, objectEncoder) || len(call.Args) == 0 {
+		return
+	}
+	key := call.Args[0]
+	value := pass.TypesInfo.Types[key].Value
+	if value == nil || value.Kind() != constant.String {
+		m.keys = append(m.keys, logKey{expr: key})
+		if name == objectEncoder+"OpenNamespace" {
+			m.bases++
+			s.base, s.prefix, s.added = m.bases, "", make(map[string]bool)
+		}
+		return
+	}
+	relative := s.prefix + constant.StringVal(value)
+	if s.base == 0 {
+		// Paths are relative to the object, whose key is only known where it is logged.
+		m.keys = append(m.keys, logKey{expr: key, path: relative})
+	} else {
+		m.keys = append(m.keys, logKey{expr: key})
+	}
+	if !opts.AllowDuplicateKeys && s.added[relative] {
+		pass.Reportf(key.Pos(), "%q key is already added to the object", constant.StringVal(value))
+	}
+	s.added[relative] = true
+	if name == objectEncoder+"OpenNamespace" {
+		// The following keys are nested in the namespace, which stays open until the end of the marshaler.
+		checkNamespaceName(pass, key)
+		s.prefix, s.added = relative+".", make(map[string]bool)
+	}
+}
+
+// encoderMethod returns the canonical full name of the zapcore.ObjectEncoder or zapcore.ArrayEncoder
+// method called by call, or "".
+func encoderMethod(pass *analysis.Pass, r *resolver, call *ast.CallExpr) string {
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	selection, ok := pass.TypesInfo.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return ""
+	}
+	fn, ok := selection.Obj().(*types.Func)
+	if !ok || r.zapPkg(fn) != zapModule+"/zapcore" {
+		return ""
+	}
+	if name := r.name(fn); strings.HasPrefix(name, objectEncoder) || strings.HasPrefix(name, arrayEncoder) {
+		return name
+	}
+	return ""
+}
+
+// isIgnoredResult reports whether the result of the call at the top of the stack is discarded.
+func isIgnoredResult(stack []ast.Node) bool {
+	if len(stack) < 2 {
+		return false
+	}
+	call := stack[len(stack)-1]
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.ExprStmt:
+		return true
+	case *ast.AssignStmt:
+		for i, rhs := range parent.Rhs {
+			if rhs == call && len(parent.Lhs) == len(parent.Rhs) {
+				id, ok := parent.Lhs[i].(*ast.Ident)
+				return ok && id.Name == "_"
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// expensiveFuncs are the functions whose calls are reported in the arguments of debug logging calls,
+// in addition to Options.ExpensiveFuncs and the String methods.
+var expensiveFuncs = []string{
+	"fmt.Sprint",
+	"fmt.Sprintf",
+	"fmt.Sprintln",
+	"encoding/json.Marshal",
+	"encoding/json.MarshalIndent",
+	"strings.Join",
+}
+
+// checkExpensiveDebugArgs reports the calls to expensive functions in the arguments of a debug logging call,
+// which are evaluated even when the debug level is disabled.
+// Calls guarded by a check of the level (e.g. logger.Check or Core().Enabled) are not reported.
+func checkExpensiveDebugArgs(pass *analysis.Pass, opts *Options, r *resolver, info logFuncInfo, args []ast.Expr, stack []ast.Node) {
+	if isLevelGuarded(pass, r, stack) {
+		return
+	}
+	for i, arg := range args {
+		values := []ast.Expr{arg}
+		call, callee := fieldCall(pass, r, arg)
+		if call != nil && !info.IsSugar {
+			// The key is usually constant, so only the values are checked.
+			values = call.Args[1:]
+		}
+		if inner, sel := stringerCall(pass.TypesInfo, arg); inner != nil && !opts.AllowStringMethods && info.IsW && i%2 == 1 &&
+			r.anyConstructor(pass.TypesInfo.TypeOf(sel.X)) == "Stringer" {
+			// Reported by checkStringerValues.
+			continue
+		}
+		if call != nil && callee.Name() == "String" && !opts.AllowStringMethods && len(call.Args) == 2 {
+			if inner, _ := stringerCall(pass.TypesInfo, call.Args[1]); inner != nil {
+				// Reported by checkStringerField.
+				continue
+			}
+		}
+		for _, value := range values {
+			ast.Inspect(value, func(node ast.Node) bool {
+				switch node := node.(type) {
+				case *ast.FuncLit:
+					// Not called by the logging call.
+					return false
+				case *ast.CallExpr:
+					reportExpensiveCall(pass, opts, r, node)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// reportExpensiveCall reports call if it calls an expensive function.
+func reportExpensiveCall(pass *analysis.Pass, opts *Options, r *resolver, call *ast.CallExpr) {
+	fn := r.callee(pass.TypesInfo, call)
+	if fn == nil {
+		return
+	}
+	if isStringMethod(fn) {
+		pass.Reportf(call.Pos(), "String is called even if the debug level is disabled, use zap.Stringer or check the level with logger.Check")
+		return
+	}
+	if name := r.name(fn); slices.Contains(expensiveFuncs, name) || slices.Contains(opts.ExpensiveFuncs, name) {
+		display := fn.Name()
+		if fn.Signature().Recv() == nil {
+			display = fn.Pkg().Name() + "." + display
+		}
+		pass.Reportf(call.Pos(), "%s is called even if the debug level is disabled, use a lazy field (e.g. zap.Object) or check the level with logger.Check", display)
+	}
+}
+
+// isStringMethod reports whether fn is a String method implementing fmt.Stringer.
+func isStringMethod(fn *types.Func) bool {
+	sig := fn.Signature()
+	if fn.Name() != "String" || sig.Recv() == nil || sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	return types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+}
+
+// isLevelGuarded reports whether the node at the top of the stack is in the body of an if statement
+// checking the level of a logger, e.g. with Core().Enabled, Level().Enabled or Check in its condition or init statement.
+func isLevelGuarded(pass *analysis.Pass, r *resolver, stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		ifStmt, ok := stack[i].(*ast.IfStmt)
+		if !ok || stack[i+1] != ifStmt.Body {
+			continue
+		}
+		guarded := false
+		check := func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || guarded {
+				return !guarded
+			}
+			if fn := r.callee(pass.TypesInfo, call); fn != nil && r.zapPkg(fn) != "" {
+				switch fn.Name() {
+				case "Enabled", "Check", "Level":
+					guarded = true
+				}
+			}
+			return !guarded
+		}
+		if ifStmt.Init != nil {
+			ast.Inspect(ifStmt.Init, check)
+		}
+		ast.Inspect(ifStmt.Cond, check)
+		if guarded {
+			return true
+		}
+	}
+	return false
+}
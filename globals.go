@@ -0,0 +1,62 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkGlobalVar reports the declarations of package-level variables holding loggers (*ast.GenDecl),
+// and their uses (*ast.Ident).
+func checkGlobalVar(pass *analysis.Pass, r *resolver, node ast.Node) {
+	switch node := node.(type) {
+	case *ast.GenDecl:
+		if node.Tok != token.VAR {
+			return
+		}
+		for _, spec := range node.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				if obj, ok := pass.TypesInfo.Defs[name].(*types.Var); ok && r.isGlobalLogger(obj) {
+					pass.Reportf(name.Pos(), "logger should not be stored in a package-level variable")
+				}
+			}
+		}
+	case *ast.Ident:
+		if obj, ok := pass.TypesInfo.Uses[node].(*types.Var); ok && r.isGlobalLogger(obj) {
+			pass.Reportf(node.Pos(), "global logger should not be used")
+		}
+	}
+}
+
+// isGlobalLogger reports whether v is a package-level variable of type *zap.Logger or *zap.SugaredLogger.
+func (r *resolver) isGlobalLogger(v *types.Var) bool {
+	if v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+		return false
+	}
+	return r.isZapPointer(v.Type(), "Logger", "SugaredLogger")
+}
+
+// checkGlobalSetter reports calls replacing global loggers outside of the main package.
+// It reports whether the callee is such a function.
+func checkGlobalSetter(pass *analysis.Pass, call *ast.CallExpr, fullName string, opts *Options) bool {
+	var allowed bool
+	switch fullName {
+	case zapModule + ".ReplaceGlobals":
+		allowed = opts.AllowReplaceGlobals
+	case zapModule + ".RedirectStdLog", zapModule + ".RedirectStdLogAt":
+		allowed = opts.AllowRedirectStdLog
+	default:
+		return false
+	}
+	if !allowed && pass.Pkg.Name() != "main" {
+		sel, _ := call.Fun.(*ast.SelectorExpr)
+		pos := call.Pos()
+		if sel != nil {
+			pos = sel.Sel.Pos()
+		}
+		pass.Reportf(pos, "zap.%s should only be called in the main package", fullName[len(zapModule+"."):])
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// loggerInterfaces resolves methods called on interfaces to the methods of the zap loggers they abstract,
+// so that calls such as `var logger Logger; logger.Info(...)` receive the same checks as calls to zap loggers.
+type loggerInterfaces struct {
+	loggers []types.Type              // *zap.Logger and *zap.SugaredLogger, if zap is imported.
+	names   []string                  // Interfaces listed in Options.LoggerInterfaces.
+	zapPkgs map[*types.Package]string // Zap packages imported, see resolver.zapPkgs.
+	zapSigs map[*types.Interface]bool // Whether the method signatures of interfaces mention zap types, computed on demand.
+}
+
+func newLoggerInterfaces(r *resolver, opts *Options) *loggerInterfaces {
+	li := &loggerInterfaces{names: opts.LoggerInterfaces, zapPkgs: r.zapPkgs, zapSigs: make(map[*types.Interface]bool)}
+	for zap, path := range r.zapPkgs {
+		if path != zapModule {
+			continue
+		}
+		for _, name := range []string{"Logger", "SugaredLogger"} {
+			if obj, ok := zap.Scope().Lookup(name).(*types.TypeName); ok {
+				li.loggers = append(li.loggers, types.NewPointer(obj.Type()))
+			}
+		}
+	}
+	return li
+}
+
+// method returns the zap method corresponding to the interface method called by call, if any.
+func (li *loggerInterfaces) method(info *types.Info, call *ast.CallExpr) *types.Func {
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return nil
+	}
+	method, ok := selection.Obj().(*types.Func)
+	if !ok {
+		return nil
+	}
+	return li.resolve(selection.Recv(), method)
+}
+
+// resolve returns the zap method corresponding to the method of the interface recv.
+// The interface must be implemented by *zap.Logger or *zap.SugaredLogger with a method signature mentioning zap types
+// (e.g. `Info(string, ...zap.Field)`), or be listed in Options.LoggerInterfaces;
+// in the latter case, only the methods with the same name and signature as a zap method are resolved.
+// Interfaces such as `interface{ Infof(string, ...any) }` are satisfied by many loggers, so they must be listed.
+func (li *loggerInterfaces) resolve(recv types.Type, method *types.Func) *types.Func {
+	iface, ok := recv.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	listed := false
+	if named, ok := types.Unalias(recv).(*types.Named); ok && named.Obj().Pkg() != nil {
+		listed = slices.Contains(li.names, cleanVendorPath(named.Obj().Pkg().Path())+"."+named.Obj().Name())
+	}
+	if !listed && !li.mentionsZap(iface) {
+		return nil
+	}
+	for _, logger := range li.loggers {
+		if !listed && !types.Implements(logger, iface) {
+			continue
+		}
+		obj, _, _ := types.LookupFieldOrMethod(logger, false, nil, method.Name())
+		// Receivers are ignored when comparing signatures.
+		if fn, ok := obj.(*types.Func); ok && types.Identical(fn.Type(), method.Type()) {
+			return fn
+		}
+	}
+	return nil
+}
+
+// mentionsZap reports whether the signature of a method of iface mentions a zap type.
+func (li *loggerInterfaces) mentionsZap(iface *types.Interface) bool {
+	mentions, ok := li.zapSigs[iface]
+	if ok {
+		return mentions
+	}
+	for method := range iface.Methods() {
+		sig := method.Signature()
+		if li.isZapType(sig.Params()) || li.isZapType(sig.Results()) {
+			mentions = true
+			break
+		}
+	}
+	li.zapSigs[iface] = mentions
+	return mentions
+}
+
+// isZapType reports whether t is, or is composed of, a named zap type.
+// Named types of other packages are not looked into.
+func (li *loggerInterfaces) isZapType(t types.Type) bool {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		return t.Obj().Pkg() != nil && li.zapPkgs[t.Obj().Pkg()] != ""
+	case *types.Pointer:
+		return li.isZapType(t.Elem())
+	case *types.Slice:
+		return li.isZapType(t.Elem())
+	case *types.Array:
+		return li.isZapType(t.Elem())
+	case *types.Map:
+		return li.isZapType(t.Key()) || li.isZapType(t.Elem())
+	case *types.Chan:
+		return li.isZapType(t.Elem())
+	case *types.Signature:
+		return li.isZapType(t.Params()) || li.isZapType(t.Results())
+	case *types.Tuple:
+		for v := range t.Variables() {
+			if li.isZapType(v.Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// logKey is a key of a log entry.
+type logKey struct {
+	expr ast.Expr
+	path string // Full dotted path of the key, including its namespaces, or "" if unknown.
+}
+
+// keyPrefix is the path of the namespace keys are added to.
+type keyPrefix struct {
+	path  string // Dotted path, ending with a dot unless empty.
+	known bool   // Whether the path could be computed, i.e. the namespaces have constant names.
+}
+
+var rootPrefix = keyPrefix{known: true}
+
+// nested returns the prefix of the keys nested in the key.
+func (p keyPrefix) nested(key logKey) keyPrefix {
+	if !p.known || key.path == "" {
+		return keyPrefix{}
+	}
+	return keyPrefix{path: key.path + ".", known: true}
+}
+
+// key returns the key expr added under the prefix.
+func (p keyPrefix) key(info *types.Info, expr ast.Expr) logKey {
+	key := logKey{expr: expr}
+	if value := info.Types[expr].Value; p.known && value != nil && value.Kind() == constant.String {
+		key.path = p.path + constant.StringVal(value)
+	}
+	return key
+}
+
+// fieldCall returns the call of arg to a zap field constructor (e.g. zap.String) and its callee, if any.
+func fieldCall(pass *analysis.Pass, r *resolver, arg ast.Expr) (*ast.CallExpr, *types.Func) {
+	call, ok := astutil.Unparen(arg).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil, nil
+	}
+	callee := r.callee(pass.TypesInfo, call)
+	if callee == nil || r.zapPkg(callee) != zapModule {
+		return nil, nil
+	}
+	return call, callee
+}
+
+// fieldKeys yields the keys of the zap fields among args, with their paths under prefix.
+// Fields following zap.Namespace are nested in it, and the fields of zap.Dict are nested in the dict.
+// The keys added by the marshaler of zap.Object are checked with the marshaler instead.
+// It returns false if yield returned false.
+func fieldKeys(pass *analysis.Pass, r *resolver, args []ast.Expr, prefix keyPrefix, yield func(logKey) bool) bool {
+	for _, arg := range args {
+		call, callee := fieldCall(pass, r, arg)
+		if call == nil {
+			continue
+		}
+		key := prefix.key(pass.TypesInfo, call.Args[0])
+		if !yield(key) {
+			return false
+		}
+		switch callee.Name() {
+		case "Namespace":
+			checkNamespaceName(pass, key.expr)
+			prefix = prefix.nested(key)
+		case "Dict":
+			checkNamespaceName(pass, key.expr)
+			if !fieldKeys(pass, r, call.Args[1:], prefix.nested(key), yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// namespaced returns the prefix of the keys added after the fields args, e.g. by a logger derived with them.
+func namespaced(pass *analysis.Pass, r *resolver, args []ast.Expr, prefix keyPrefix) keyPrefix {
+	for _, arg := range args {
+		if call, callee := fieldCall(pass, r, arg); call != nil && callee.Name() == "Namespace" {
+			prefix = prefix.nested(prefix.key(pass.TypesInfo, call.Args[0]))
+		}
+	}
+	return prefix
+}
+
+// checkNamespaceName reports namespaces whose name would produce ambiguous key paths.
+func checkNamespaceName(pass *analysis.Pass, expr ast.Expr) {
+	value := pass.TypesInfo.Types[expr].Value
+	if value == nil || value.Kind() != constant.String {
+		return
+	}
+	switch name := constant.StringVal(value); {
+	case name == "":
+		pass.Reportf(expr.Pos(), "namespace name should not be empty")
+	case strings.Contains(name, "."):
+		pass.Reportf(expr.Pos(), "namespace name %q should not contain dots", name)
+	}
+}
+
+// keyPaths computes the prefixes of the keys added by loggers,
+// from the namespaces added with With to the receivers of logging calls.
+// When the construction of a logger cannot be traced (e.g. parameters), paths are relative to it.
+type keyPaths struct {
+	pass *analysis.Pass
+	r    *resolver
+	defs map[*types.Var]ast.Expr // Values of the local variables assigned once. Computed on demand.
+}
+
+// prefix returns the prefix of the keys added by the logger expr.
+func (k *keyPaths) prefix(expr ast.Expr) keyPrefix {
+	return k.prefixOf(expr, make(map[*types.Var]bool))
+}
+
+func (k *keyPaths) prefixOf(expr ast.Expr, seen map[*types.Var]bool) keyPrefix {
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		v, ok := k.pass.TypesInfo.Uses[expr].(*types.Var)
+		if !ok || seen[v] {
+			return rootPrefix
+		}
+		seen[v] = true
+		if v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+			// Package-level loggers may be reassigned anywhere.
+			return rootPrefix
+		}
+		if value, ok := k.values()[v]; ok {
+			return k.prefixOf(value, seen)
+		}
+		// Parameters and variables assigned several times.
+		return rootPrefix
+	case *ast.CallExpr:
+		fn := k.r.callee(k.pass.TypesInfo, expr)
+		if fn == nil {
+			return rootPrefix
+		}
+		info, ok := k.r.lookup(fn)
+		if !ok {
+			if k.r.name(fn) == zapModule+".Must" && len(expr.Args) > 0 {
+				return k.prefixOf(expr.Args[0], seen)
+			}
+			return rootPrefix
+		}
+		sel, ok := expr.Fun.(*ast.SelectorExpr)
+		if !info.Derives || !ok {
+			return rootPrefix
+		}
+		prefix := k.prefixOf(sel.X, seen)
+		if fn.Name() == "With" || fn.Name() == "WithLazy" {
+			prefix = namespaced(k.pass, k.r, expr.Args, prefix)
+		}
+		return prefix
+	}
+	return rootPrefix
+}
+
+// values returns the values of the local variables defined and never reassigned.
+func (k *keyPaths) values() map[*types.Var]ast.Expr {
+	if k.defs != nil {
+		return k.defs
+	}
+	k.defs = make(map[*types.Var]ast.Expr)
+	defined := make(map[*types.Var]bool)
+	reassigned := make(map[*types.Var]bool) // Variables whose value depends on the control flow.
+	variable := func(expr ast.Expr) (*types.Var, bool) {
+		id, ok := expr.(*ast.Ident)
+		if !ok {
+			return nil, false
+		}
+		v, ok := k.pass.TypesInfo.ObjectOf(id).(*types.Var)
+		return v, ok
+	}
+	assign := func(lhs, rhs []ast.Expr) {
+		for i, expr := range lhs {
+			v, ok := variable(expr)
+			if !ok {
+				continue
+			}
+			if defined[v] {
+				// e.g. err in a second `x, err := f()`.
+				reassigned[v] = true
+			}
+			defined[v] = true
+			switch {
+			case len(lhs) == len(rhs):
+				k.defs[v] = rhs[i]
+			case len(rhs) == 1 && i == 0:
+				// e.g. logger, err := zap.NewProduction()
+				k.defs[v] = rhs[0]
+			}
+		}
+	}
+	for _, file := range k.pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.AssignStmt:
+				assign(node.Lhs, node.Rhs)
+				if node.Tok != token.DEFINE {
+					for _, lhs := range node.Lhs {
+						if v, ok := variable(lhs); ok {
+							reassigned[v] = true
+						}
+					}
+				}
+			case *ast.RangeStmt:
+				for _, expr := range []ast.Expr{node.Key, node.Value} {
+					if v, ok := variable(expr); ok {
+						reassigned[v] = true
+					}
+				}
+			case *ast.IncDecStmt:
+				if v, ok := variable(node.X); ok {
+					reassigned[v] = true
+				}
+			case *ast.ValueSpec:
+				lhs := make([]ast.Expr, len(node.Names))
+				for i, name := range node.Names {
+					lhs[i] = name
+				}
+				assign(lhs, node.Values)
+			case *ast.UnaryExpr:
+				// The variable may be modified through its address.
+				if v, ok := variable(node.X); ok && node.Op == token.AND {
+					reassigned[v] = true
+				}
+			}
+			return true
+		})
+	}
+	for v := range reassigned {
+		delete(k.defs, v)
+	}
+	return k.defs
+}
@@ -0,0 +1,229 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkDeriveInLoop reports calls deriving a logger (With, Named, Sugar, etc.) inside a loop
+// on a receiver that does not change between iterations, which clones the logger on every iteration.
+// Hoisting the call is only suggested if its arguments do not change between iterations either.
+func checkDeriveInLoop(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, stack []ast.Node) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	loop := enclosingLoop(stack)
+	if loop == nil || !isLoopInvariant(pass.TypesInfo, sel.X, loop) {
+		return
+	}
+	switch fn.Name() {
+	case "Sugar", "Desugar":
+		pass.Reportf(sel.Sel.Pos(), "%s should not be called inside a loop, convert the logger once before the loop", fn.Name())
+	default:
+		switch {
+		case areLoopInvariant(pass.TypesInfo, call.Args, loop):
+			pass.Reportf(sel.Sel.Pos(), "%s should not be called inside a loop on the same logger, hoist it out of the loop", fn.Name())
+		case fn.Name() == "With" || fn.Name() == "WithLazy":
+			pass.Reportf(sel.Sel.Pos(), "%s should not be called inside a loop on the same logger, pass the fields to the logging calls instead", fn.Name())
+		default:
+			pass.Reportf(sel.Sel.Pos(), "%s should not be called inside a loop on the same logger", fn.Name())
+		}
+	}
+}
+
+// areLoopInvariant reports whether the variables used by exprs are all loop-invariant (see isLoopInvariant).
+func areLoopInvariant(info *types.Info, exprs []ast.Expr, loop ast.Stmt) bool {
+	invariant := true
+	for _, expr := range exprs {
+		ast.Inspect(expr, func(node ast.Node) bool {
+			if id, ok := node.(*ast.Ident); ok {
+				if v, ok := info.Uses[id].(*types.Var); ok && !v.IsField() && !isLoopInvariant(info, id, loop) {
+					invariant = false
+				}
+			}
+			return invariant
+		})
+	}
+	return invariant
+}
+
+// enclosingLoop returns the innermost loop whose body contains the node at the top of the stack, if any,
+// within the same function.
+func enclosingLoop(stack []ast.Node) ast.Stmt {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return nil
+		case *ast.ForStmt:
+			if stack[i+1] == node.Body {
+				return node
+			}
+		case *ast.RangeStmt:
+			if stack[i+1] == node.Body {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+// isLoopInvariant reports whether expr, made of variables, fields and dereferences, has the same value in all the
+// iterations of the loop, i.e. its variables are declared outside of the loop and not assigned in it.
+func isLoopInvariant(info *types.Info, expr ast.Expr, loop ast.Stmt) bool {
+	var root *types.Var
+	for root == nil {
+		switch e := astutil.Unparen(expr).(type) {
+		case *ast.Ident:
+			v, ok := info.Uses[e].(*types.Var)
+			if !ok || (v.Pos() >= loop.Pos() && v.Pos() < loop.End()) {
+				return false
+			}
+			root = v
+		case *ast.SelectorExpr:
+			if _, ok := info.Selections[e]; !ok {
+				// Package-level variable of another package.
+				v, ok := info.Uses[e.Sel].(*types.Var)
+				return ok && !isAssignedIn(info, loop, v)
+			}
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			// e.g. calls, whose results may differ.
+			return false
+		}
+	}
+	return !isAssignedIn(info, loop, root)
+}
+
+// isAssignedIn reports whether the variable v, or one of its fields, is assigned in node
+// or may be through its address.
+func isAssignedIn(info *types.Info, node ast.Node, v *types.Var) bool {
+	// rootVar returns the variable of an expression like v, v.f or *v.
+	rootVar := func(expr ast.Expr) types.Object {
+		for {
+			switch e := astutil.Unparen(expr).(type) {
+			case *ast.Ident:
+				return info.ObjectOf(e)
+			case *ast.SelectorExpr:
+				if _, ok := info.Selections[e]; !ok {
+					return info.ObjectOf(e.Sel)
+				}
+				expr = e.X
+			case *ast.StarExpr:
+				expr = e.X
+			case *ast.IndexExpr:
+				expr = e.X
+			default:
+				return nil
+			}
+		}
+	}
+	assigned := false
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				assigned = assigned || rootVar(lhs) == v
+			}
+		case *ast.IncDecStmt:
+			assigned = assigned || rootVar(node.X) == v
+		case *ast.UnaryExpr:
+			assigned = assigned || (node.Op == token.AND && rootVar(node.X) == v)
+		}
+		return !assigned
+	})
+	return assigned
+}
+
+// checkLoopLogging reports the calls logging at the info level or lower directly inside loops,
+// unless they handle an error or the logger is sampled.
+// Logging on every iteration of a tight loop (e.g. per packet) can overwhelm the logging pipeline.
+// The calls are collected by isLoopLogging.
+func checkLoopLogging(pass *analysis.Pass, r *resolver, calls []*ast.CallExpr) {
+	ssainfo := r.ssaPackage(pass)
+	ssaCalls := ssaCallsByPos(ssainfo.SrcFuncs)
+	tracer := newSamplingTracer(r, ssainfo)
+	for _, call := range calls {
+		if ssaCall, ok := ssaCalls[call.Lparen]; ok {
+			if logger := receiver(ssaCall.Common()); logger != nil && tracer.trace(logger) {
+				continue
+			}
+		}
+		pass.Reportf(call.Pos(), "logging inside a loop should use a sampled logger or be moved out of the loop")
+	}
+}
+
+// isLoopLogging reports whether the call at the top of the stack logs at the info level or lower inside a loop,
+// outside of error handling.
+func isLoopLogging(pass *analysis.Pass, r *resolver, call *ast.CallExpr, stack []ast.Node) bool {
+	fn := r.callee(pass.TypesInfo, call)
+	if fn == nil || fn.Name() == "Check" {
+		return false
+	}
+	info, ok := r.lookup(fn)
+	if !ok || !info.HasMsg {
+		return false
+	}
+	if level, ok := logLevel(pass.TypesInfo, call, info); !ok || (level != levelDebug && level != levelInfo) {
+		return false
+	}
+	loop := enclosingLoop(stack)
+	return loop != nil && !isErrorHandling(pass.TypesInfo, stack, loop)
+}
+
+// isErrorHandling reports whether the node at the top of the stack is in a branch of an if statement
+// checking an error (e.g. err != nil), inside the loop.
+func isErrorHandling(info *types.Info, stack []ast.Node, loop ast.Stmt) bool {
+	for i := len(stack) - 2; i >= 0 && stack[i] != loop; i-- {
+		ifStmt, ok := stack[i].(*ast.IfStmt)
+		if !ok || stack[i+1] == ifStmt.Cond || stack[i+1] == ifStmt.Init {
+			continue
+		}
+		checksError := false
+		ast.Inspect(ifStmt.Cond, func(node ast.Node) bool {
+			if expr, ok := node.(ast.Expr); ok && !checksError {
+				if t := info.TypeOf(expr); t != nil && isError(t) {
+					checksError = true
+				}
+			}
+			return !checksError
+		})
+		if checksError {
+			return true
+		}
+	}
+	return false
+}
+
+// ssaCallsByPos maps the positions of the calls of funcs (the positions of their left parentheses) to the calls.
+func ssaCallsByPos(funcs []*ssa.Function) map[token.Pos]*ssa.Call {
+	calls := make(map[token.Pos]*ssa.Call)
+	for _, fn := range funcs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				if call, ok := instr.(*ssa.Call); ok {
+					calls[call.Pos()] = call
+				}
+			}
+		}
+	}
+	return calls
+}
+
+// receiver returns the receiver of a method call, or nil.
+func receiver(call *ssa.CallCommon) ssa.Value {
+	if call.IsInvoke() {
+		return call.Value
+	}
+	if call.Signature().Recv() != nil && len(call.Args) > 0 {
+		return call.Args[0]
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package zaplint
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// errorFieldFuncs maps zap error field constructors to the position of their error argument.
+// zap.Errors is not listed: it takes a slice of errors, which is not an error when nil.
+var errorFieldFuncs = map[string]int{
+	zapModule + ".Error":      0,
+	zapModule + ".NamedError": 1,
+}
+
+// checkNilErrors reports errors passed to zap that are provably nil at the point of the call,
+// e.g. zap.Error(err) inside an `if err == nil` branch.
+func checkNilErrors(pass *analysis.Pass, r *resolver) {
+	ssainfo := r.ssaPackage(pass)
+	for _, fn := range ssainfo.SrcFuncs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				obj := r.ssaCallee(call.Common())
+				if obj == nil {
+					continue
+				}
+				fullName := r.name(obj)
+				args := call.Common().Args
+				if pos, ok := errorFieldFuncs[fullName]; ok {
+					if len(args) > pos && isNil(args[pos], b) {
+						pass.Reportf(call.Pos(), "logged error is always nil")
+					}
+					continue
+				}
+				info, ok := r.lookup(obj)
+				if !ok || !info.IsSugar || !(info.IsW || obj.Name() == "With" || obj.Name() == "WithLazy") || len(args) == 0 {
+					continue
+				}
+				// The key-value pairs are passed as the variadic (last) argument.
+				for i, v := range variadicArgs(args[len(args)-1]) {
+					if i%2 == 1 && isError(v.Type()) && isNil(v, b) {
+						pass.Reportf(call.Pos(), "logged error is always nil")
+					}
+				}
+			}
+		}
+	}
+}
+
+// variadicArgs returns the values stored in the slice created for a variadic ...any parameter,
+// before their conversion to interfaces, indexed by their position.
+func variadicArgs(v ssa.Value) map[int]ssa.Value {
+	slice, ok := v.(*ssa.Slice)
+	if !ok {
+		return nil
+	}
+	alloc, ok := slice.X.(*ssa.Alloc)
+	if !ok {
+		return nil
+	}
+	values := make(map[int]ssa.Value)
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		index, ok := addr.Index.(*ssa.Const)
+		if !ok {
+			continue
+		}
+		for _, ref := range *addr.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+				val := store.Val
+				switch conv := val.(type) {
+				case *ssa.MakeInterface:
+					val = conv.X
+				case *ssa.ChangeInterface:
+					val = conv.X
+				}
+				values[int(index.Int64())] = val
+			}
+		}
+	}
+	return values
+}
+
+// isError reports whether t is an interface type implementing error.
+func isError(t types.Type) bool {
+	return types.IsInterface(t) && types.Implements(t, types.Universe.Lookup("error").Type().Underlying().(*types.Interface))
+}
+
+// isNil reports whether v is provably nil in block b, either because it is a nil constant
+// or because b is only reachable through a branch comparing v to nil.
+func isNil(v ssa.Value, b *ssa.BasicBlock) bool {
+	if c, ok := v.(*ssa.Const); ok {
+		return c.IsNil()
+	}
+	for dom := b; dom != nil; dom = dom.Idom() {
+		if len(dom.Preds) != 1 {
+			continue
+		}
+		pred := dom.Preds[0]
+		ifInstr, ok := pred.Instrs[len(pred.Instrs)-1].(*ssa.If)
+		if !ok {
+			continue
+		}
+		cond, ok := ifInstr.Cond.(*ssa.BinOp)
+		if !ok || (cond.Op != token.EQL && cond.Op != token.NEQ) {
+			continue
+		}
+		if !(cond.X == v && isNilConst(cond.Y)) && !(cond.Y == v && isNilConst(cond.X)) {
+			continue
+		}
+		return (dom == pred.Succs[0]) == (cond.Op == token.EQL)
+	}
+	return false
+}
+
+func isNilConst(v ssa.Value) bool {
+	c, ok := v.(*ssa.Const)
+	return ok && c.IsNil()
+}
@@ -0,0 +1,322 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"maps"
+	"math"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/ssa"
+)
+
+// entryKeys maps the keys of the metadata of log entries (time, level, message, etc.)
+// to the name of the zapcore.EncoderConfig field setting them.
+type entryKeys map[string]string
+
+// Keys set by zap.NewProductionEncoderConfig and zap.NewDevelopmentEncoderConfig.
+var (
+	productionEntryKeys = entryKeys{
+		"ts":         "TimeKey",
+		"level":      "LevelKey",
+		"logger":     "NameKey",
+		"caller":     "CallerKey",
+		"msg":        "MessageKey",
+		"stacktrace": "StacktraceKey",
+	}
+	developmentEntryKeys = entryKeys{
+		"T": "TimeKey",
+		"L": "LevelKey",
+		"N": "NameKey",
+		"C": "CallerKey",
+		"M": "MessageKey",
+		"S": "StacktraceKey",
+	}
+)
+
+// entryKeyNames describes the metadata set by the key fields of zapcore.EncoderConfig.
+var entryKeyNames = map[string]string{
+	"TimeKey":       "time",
+	"LevelKey":      "level",
+	"NameKey":       "logger name",
+	"CallerKey":     "caller",
+	"FunctionKey":   "function",
+	"MessageKey":    "message",
+	"StacktraceKey": "stack trace",
+}
+
+// encoderKey is a key of the entries set by a field of zapcore.EncoderConfig.
+type encoderKey struct {
+	key   string
+	field string    // Name of the EncoderConfig field, e.g. "MessageKey".
+	pos   token.Pos // Position of the value or of the default configuration setting the key.
+}
+
+// configuredKeys returns the keys of the entries configured by the package: the keys of its zapcore.EncoderConfig
+// literals and assignments, and the keys of the default encoder configurations it uses that are not set explicitly.
+func configuredKeys(pass *analysis.Pass, inspector *inspector.Inspector, r *resolver) []encoderKey {
+	var keys, defaults []encoderKey
+	set := make(map[string]bool) // Fields set explicitly, even to empty keys.
+	// add adds the key set by value to the field of an encoder config, ignoring the omitted (empty) keys.
+	add := func(field *types.Var, value ast.Expr) {
+		if _, ok := entryKeyNames[field.Name()]; !ok {
+			return
+		}
+		set[field.Name()] = true
+		if v := pass.TypesInfo.Types[value].Value; v != nil && v.Kind() == constant.String && constant.StringVal(v) != "" {
+			keys = append(keys, encoderKey{key: constant.StringVal(v), field: field.Name(), pos: value.Pos()})
+		}
+	}
+	nodeFilter := []ast.Node{(*ast.CompositeLit)(nil), (*ast.AssignStmt)(nil), (*ast.CallExpr)(nil)}
+	inspector.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.CompositeLit:
+			if r.configType(pass.TypesInfo.TypeOf(node)) != encoderConfig {
+				return
+			}
+			for _, elt := range node.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if id, ok := kv.Key.(*ast.Ident); ok {
+						if field, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+							add(field, kv.Value)
+						}
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return
+			}
+			for i, lhs := range node.Lhs {
+				sel, ok := astutil.Unparen(lhs).(*ast.SelectorExpr)
+				if !ok {
+					continue
+				}
+				if selection, ok := pass.TypesInfo.Selections[sel]; ok && selection.Kind() == types.FieldVal && r.configType(pass.TypesInfo.TypeOf(sel.X)) == encoderConfig {
+					add(selection.Obj().(*types.Var), node.Rhs[i])
+				}
+			}
+		case *ast.CallExpr:
+			fn := r.callee(pass.TypesInfo, node)
+			if fn == nil || r.zapPkg(fn) != zapModule {
+				return
+			}
+			var keys entryKeys
+			switch fn.Name() {
+			case "NewProductionEncoderConfig", "NewProductionConfig", "NewProduction":
+				keys = productionEntryKeys
+			case "NewDevelopmentEncoderConfig", "NewDevelopmentConfig", "NewDevelopment":
+				keys = developmentEntryKeys
+			default:
+				return
+			}
+			for key, field := range keys {
+				defaults = append(defaults, encoderKey{key: key, field: field, pos: node.Pos()})
+			}
+		}
+	})
+	for _, k := range defaults {
+		if !set[k.field] {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// stackField is a zap.Stack or zap.StackSkip field of a logging call at the error level or above.
+type stackField struct {
+	call  *ast.CallExpr // Logging call.
+	field *ast.CallExpr
+	name  string // "Stack" or "StackSkip".
+	level string
+}
+
+// checkRedundantFields reports the fields of a logged entry duplicating its metadata:
+// the current time, which the entry records. The stack traces at the error level and above are appended
+// to stacks, to be checked against the construction of the logger by checkRedundantStacks.
+func checkRedundantFields(pass *analysis.Pass, r *resolver, info logFuncInfo, call *ast.CallExpr, level string, args []ast.Expr, stacks *[]stackField) {
+	if info.IsSugar {
+		if !info.IsW {
+			return
+		}
+		for i := 1; i < len(args); i += 2 {
+			if isTimeNow(pass.TypesInfo, r, args[i]) {
+				pass.Reportf(args[i].Pos(), "time.Now() duplicates the time of the entry")
+			}
+		}
+		return
+	}
+	for _, arg := range args {
+		field, callee := fieldCall(pass, r, arg)
+		if field == nil {
+			continue
+		}
+		switch callee.Name() {
+		case "Stack", "StackSkip":
+			switch level {
+			case levelError, levelDPanic, levelPanic, levelFatal:
+				*stacks = append(*stacks, stackField{call: call, field: field, name: callee.Name(), level: level})
+			}
+		case "Dict", "Namespace":
+		default:
+			for _, value := range field.Args[1:] {
+				if isTimeNow(pass.TypesInfo, r, value) {
+					pass.Reportf(value.Pos(), "time.Now() duplicates the time of the entry")
+				}
+			}
+		}
+	}
+}
+
+// checkRedundantStacks reports the stack fields logged with loggers adding stack traces at their level,
+// as traced back to their construction: zap.NewProduction (error level), zap.NewDevelopment (warn level),
+// zap.Config.Build unless DisableStacktrace is set, or a zap.AddStacktrace option.
+func checkRedundantStacks(pass *analysis.Pass, r *resolver, stacks []stackField) {
+	ssainfo := r.ssaPackage(pass)
+	ssaCalls := ssaCallsByPos(ssainfo.SrcFuncs)
+	tracer := newStackTracer(r, ssainfo)
+	for _, s := range stacks {
+		ssaCall, ok := ssaCalls[s.call.Lparen]
+		if !ok {
+			continue
+		}
+		logger := receiver(ssaCall.Common())
+		if logger == nil || tracer.trace(logger) > zapLevelValues[s.level] {
+			continue
+		}
+		pass.Reportf(s.field.Pos(), "zap.%s duplicates the stack trace added to %s entries by zap.AddStacktrace", s.name, s.level)
+	}
+}
+
+// noStacktrace is the stack trace level of the loggers not adding stack traces, or whose level is unknown.
+const noStacktrace = math.MaxInt64
+
+// stackHandler computes the lowest level at which loggers add stack traces, or noStacktrace.
+// Loggers whose construction cannot be traced (e.g. parameters) add none.
+type stackHandler struct {
+	resolver *resolver
+}
+
+func newStackTracer(r *resolver, ssainfo *buildssa.SSA) *loggerTracer[int64] {
+	return newLoggerTracer[int64](r, ssainfo, stackHandler{resolver: r})
+}
+
+func (stackHandler) core(ssa.Value) int64 { return noStacktrace }
+func (stackHandler) unknown() int64       { return noStacktrace }
+func (stackHandler) visiting() int64      { return math.MinInt64 }
+
+// preset returns the level of the constructor: error for production loggers, warn for development ones.
+func (stackHandler) preset(name string) int64 {
+	switch name {
+	case zapModule + ".NewProduction":
+		return zapLevelValues[levelError]
+	case zapModule + ".NewDevelopment":
+		return zapLevelValues[levelWarn]
+	}
+	return noStacktrace
+}
+
+// join returns the highest level of the loggers, so that the level applies to all of them.
+func (stackHandler) join(values []int64) int64 {
+	if len(values) == 0 {
+		return noStacktrace
+	}
+	return slices.Max(values)
+}
+
+// config returns the level at which the loggers built by the zap.Config v add stack traces:
+// the warn level for the development configuration, the error level otherwise, unless DisableStacktrace is set.
+func (h stackHandler) config(v ssa.Value) int64 {
+	switch v := v.(type) {
+	case *ssa.Call:
+		switch h.resolver.ssaCalleeName(v.Common()) {
+		case zapModule + ".NewProductionConfig":
+			return zapLevelValues[levelError]
+		case zapModule + ".NewDevelopmentConfig":
+			return zapLevelValues[levelWarn]
+		}
+	case *ssa.Const:
+		// The zero configuration.
+		return zapLevelValues[levelError]
+	case *ssa.UnOp:
+		alloc, ok := v.X.(*ssa.Alloc)
+		if !ok {
+			return noStacktrace
+		}
+		// The last store wins, in the order of the instructions of the function.
+		level := zapLevelValues[levelError]
+		for _, ref := range *alloc.Referrers() {
+			switch ref := ref.(type) {
+			case *ssa.Store:
+				if ref.Addr == alloc {
+					level = h.config(ref.Val)
+				}
+			case *ssa.FieldAddr:
+				if fieldOf(ref).Name() != "DisableStacktrace" {
+					continue
+				}
+				for _, fieldRef := range *ref.Referrers() {
+					if store, ok := fieldRef.(*ssa.Store); ok && store.Addr == ref {
+						if c, isConst := store.Val.(*ssa.Const); !isConst || constant.BoolVal(c.Value) {
+							level = noStacktrace
+						}
+					}
+				}
+			}
+		}
+		return level
+	}
+	return noStacktrace
+}
+
+// options returns the level of the last zap.AddStacktrace option passed as the variadic argument v,
+// or level if there is none. Levels that are not constant are unknown.
+func (h stackHandler) options(v ssa.Value, level int64) int64 {
+	opts := variadicArgs(v)
+	for _, i := range slices.Sorted(maps.Keys(opts)) {
+		call, ok := opts[i].(*ssa.Call)
+		if !ok || h.resolver.ssaCalleeName(call.Common()) != zapModule+".AddStacktrace" || len(call.Call.Args) != 1 {
+			continue
+		}
+		level = noStacktrace
+		if iface, ok := call.Call.Args[0].(*ssa.MakeInterface); ok {
+			if c, ok := iface.X.(*ssa.Const); ok && c.Value != nil {
+				if n, exact := constant.Int64Val(c.Value); exact {
+					level = n
+				}
+			}
+		}
+	}
+	return level
+}
+
+// timeNowMethods are the methods of time.Time converting the time, rather than computing another one from it.
+var timeNowMethods = []string{"UTC", "Local", "In", "Round", "Truncate", "Unix", "UnixMilli", "UnixMicro", "UnixNano", "Format", "String"}
+
+// isTimeNow reports whether expr is time.Now(), or a conversion of it (e.g. time.Now().UTC().Unix()).
+func isTimeNow(info *types.Info, r *resolver, expr ast.Expr) bool {
+	for {
+		call, ok := astutil.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		fn := r.callee(info, call)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "time" {
+			return false
+		}
+		if fn.Name() == "Now" && fn.Signature().Recv() == nil {
+			return true
+		}
+		sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok || fn.Signature().Recv() == nil || !slices.Contains(timeNowMethods, fn.Name()) {
+			return false
+		}
+		expr = sel.X
+	}
+}
@@ -0,0 +1,53 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// reflectionPolicy returns the reflection policy of the given package.
+func (opts *Options) reflectionPolicy(pkgPath string) string {
+	policy := opts.ReflectionPolicy
+	for _, o := range opts.Overrides {
+		if o.ReflectionPolicy != "" && o.matches(pkgPath) {
+			policy = o.ReflectionPolicy
+		}
+	}
+	return policy
+}
+
+// isReflected reports whether zap.Any encodes values of type t with reflection,
+// i.e. t is not an interface and has no case in its type switch.
+func (r *resolver) isReflected(t types.Type) bool {
+	if basic, ok := t.(*types.Basic); ok && basic.Info()&types.IsUntyped != 0 {
+		return false
+	}
+	return len(r.anyCases()) > 0 && !types.IsInterface(t) && r.anyConstructor(t) == ""
+}
+
+// checkReflectedField reports calls to zap.Reflect, and to zap.Any with values encoded with reflection.
+func checkReflectedField(pass *analysis.Pass, r *resolver, call *ast.CallExpr, name string) {
+	if len(call.Args) != 2 {
+		return
+	}
+	switch name {
+	case "Reflect":
+		pass.Reportf(call.Pos(), "zap.Reflect encodes the value with reflection, which is forbidden in this package")
+	case "Any":
+		if t := pass.TypesInfo.TypeOf(call.Args[1]); t != nil && r.isReflected(t) {
+			pass.Reportf(call.Args[1].Pos(), "zap.Any encodes %s values with reflection, which is forbidden in this package", types.TypeString(t, qualifier(pass.Pkg)))
+		}
+	}
+}
+
+// checkReflectedValues reports the values of the key-value pairs of sugared loggers encoded with reflection,
+// since they are converted to fields with zap.Any.
+func checkReflectedValues(pass *analysis.Pass, r *resolver, args []ast.Expr) {
+	for i := 1; i < len(args); i += 2 {
+		if t := pass.TypesInfo.TypeOf(args[i]); t != nil && r.isReflected(t) {
+			pass.Reportf(args[i].Pos(), "sugared logger encodes %s values with reflection, which is forbidden in this package", types.TypeString(t, qualifier(pass.Pkg)))
+		}
+	}
+}
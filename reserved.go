@@ -0,0 +1,101 @@
+package zaplint
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// reservedKey is a key of the entries set by a zapcore.EncoderConfig of the program.
+type reservedKey struct {
+	Key   string
+	Field string // Name of the EncoderConfig field, e.g. "MessageKey".
+	Pos   string // Position where the key is set, e.g. "example.com/app/config/config.go:12".
+}
+
+// encoderKeysFact is exported for packages configuring the keys of zapcore.EncoderConfig,
+// so that the keys are reserved in the packages importing them.
+type encoderKeysFact struct {
+	Keys []reservedKey
+}
+
+func (*encoderKeysFact) AFact() {}
+
+func (f *encoderKeysFact) String() string {
+	return fmt.Sprintf("zap encoder keys(%d)", len(f.Keys))
+}
+
+// reservedKeys maps the keys reserved in the analyzed package to where they are set.
+// It is the result of the encoder keys analyzer.
+type reservedKeys map[string]reservedKey
+
+// entryKeys returns the keys of the entries configured by the program, without their positions.
+func (keys reservedKeys) entryKeys() entryKeys {
+	entry := make(entryKeys, len(keys))
+	for key, rk := range keys {
+		entry[key] = rk.Field
+	}
+	return entry
+}
+
+// newEncoderKeysAnalyzer creates the analyzer collecting the keys set by the encoder configurations of the program
+// (Options.ForbidEncoderKeys and Options.AllowRedundantFields).
+func newEncoderKeysAnalyzer(opts *Options) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:       "zaplintencoderkeys",
+		Doc:        "collect the keys set by go.uber.org/zap encoder configurations",
+		Requires:   []*analysis.Analyzer{opts.wrappers},
+		FactTypes:  []analysis.Fact{new(encoderKeysFact)},
+		ResultType: reflect.TypeFor[reservedKeys](),
+		Run: func(pass *analysis.Pass) (any, error) {
+			if !opts.ForbidEncoderKeys && opts.AllowRedundantFields {
+				return reservedKeys(nil), nil
+			}
+			return collectReservedKeys(pass, opts), nil
+		},
+	}
+}
+
+// collectReservedKeys exports the keys configured by the package as a fact,
+// and returns them with the keys of the facts of its dependencies.
+// When several configurations set the same key, the lowest position in lexical order wins so that reports are stable.
+func collectReservedKeys(pass *analysis.Pass, opts *Options) reservedKeys {
+	reserved := make(reservedKeys)
+	add := func(k reservedKey) {
+		if prev, ok := reserved[k.Key]; !ok || k.Pos < prev.Pos {
+			reserved[k.Key] = k
+		}
+	}
+	for _, fact := range pass.AllPackageFacts() {
+		for _, k := range fact.Fact.(*encoderKeysFact).Keys {
+			add(k)
+		}
+	}
+	r := pass.ResultOf[opts.wrappers].(*wrappers).resolver
+	if !r.importsZap() || opts.isZapPkg(pass.Pkg.Path()) {
+		return reserved
+	}
+	inspector := r.inspector(pass)
+	keys := configuredKeys(pass, inspector, r)
+	if len(keys) == 0 {
+		return reserved
+	}
+	slices.SortFunc(keys, func(a, b encoderKey) int { return cmp.Compare(a.key, b.key) })
+	fact := new(encoderKeysFact)
+	for _, k := range keys {
+		position := pass.Fset.Position(k.pos)
+		rk := reservedKey{
+			Key:   k.key,
+			Field: k.field,
+			Pos:   fmt.Sprintf("%s/%s:%d", pass.Pkg.Path(), filepath.Base(position.Filename), position.Line),
+		}
+		fact.Keys = append(fact.Keys, rk)
+		add(rk)
+	}
+	pass.ExportPackageFact(fact)
+	return reserved
+}
@@ -0,0 +1,243 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// funcRef identifies a function or a method by its name in its package.
+type funcRef struct {
+	Recv string // Name of the receiver type, for methods.
+	Name string
+}
+
+// parseFuncName splits the full name of a function (as returned by types.Func.FullName)
+// into its package path and reference, e.g. "(*go.uber.org/zap.Logger).Info" into "go.uber.org/zap" and {Logger, Info}.
+func parseFuncName(fullName string) (string, funcRef) {
+	var recv string
+	if strings.HasPrefix(fullName, "(") {
+		end := strings.Index(fullName, ")")
+		if end == -1 {
+			return "", funcRef{}
+		}
+		recv = strings.TrimPrefix(fullName[1:end], "*")
+		fullName = recv + fullName[end+1:]
+	}
+	dot := strings.LastIndex(fullName, ".")
+	if dot == -1 {
+		return "", funcRef{Name: fullName}
+	}
+	name := fullName[dot+1:]
+	if recv == "" {
+		return fullName[:dot], funcRef{Name: name}
+	}
+	pkgPath := fullName[:dot]
+	typeDot := strings.LastIndex(pkgPath, ".")
+	if typeDot == -1 {
+		return "", funcRef{}
+	}
+	return pkgPath[:typeDot], funcRef{Recv: pkgPath[typeDot+1:], Name: name}
+}
+
+// resolver resolves the functions called in a package to the logging functions they are.
+// The logging functions (zap methods and configured wrappers) are resolved once per package
+// from the packages it imports, so that calls are looked up by *types.Func instead of by name.
+type resolver struct {
+	opts    *Options
+	ifaces  *loggerInterfaces
+	zapPkgs map[*types.Package]string     // Canonical paths of the zap packages imported, directly or not.
+	funcs   map[*types.Func]logFuncInfo   // Resolved Options.funcs.
+	facts   map[*types.Func]*wrapperFact  // Detected wrappers of the package and its dependencies.
+	names   map[*types.Func]string        // Canonical full names, computed on demand.
+	callees map[*ast.CallExpr]*types.Func // Callees of the calls of the package, computed on demand.
+
+	anySwitch []anyCase            // Cases of the type switch of zap.Any, computed on demand.
+	inspect   *inspector.Inspector // Inspector of the files of the package, built on demand.
+	ssa       *buildssa.SSA        // SSA form of the package, built on demand.
+}
+
+func newResolver(pkg *types.Package, opts *Options) *resolver {
+	r := &resolver{
+		opts:    opts,
+		zapPkgs: make(map[*types.Package]string),
+		funcs:   make(map[*types.Func]logFuncInfo),
+		facts:   make(map[*types.Func]*wrapperFact),
+		names:   make(map[*types.Func]string),
+		callees: make(map[*ast.CallExpr]*types.Func),
+	}
+	r.resolveFuncs(pkg)
+	seen := make(map[*types.Package]bool)
+	var walk func(pkg *types.Package)
+	walk = func(pkg *types.Package) {
+		for _, imp := range pkg.Imports() {
+			if seen[imp] {
+				continue
+			}
+			seen[imp] = true
+			if opts.isZapPkg(imp.Path()) {
+				r.zapPkgs[imp] = opts.canonical(imp.Path())
+			}
+			r.resolveFuncs(imp)
+			walk(imp)
+		}
+	}
+	walk(pkg)
+	r.ifaces = newLoggerInterfaces(r, opts)
+	return r
+}
+
+// resolveFuncs resolves the logging functions declared in pkg.
+func (r *resolver) resolveFuncs(pkg *types.Package) {
+	refs := r.opts.funcs[r.opts.canonical(pkg.Path())]
+	for ref, info := range refs {
+		obj := pkg.Scope().Lookup(ref.Name)
+		if ref.Recv != "" {
+			recv, ok := pkg.Scope().Lookup(ref.Recv).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			obj, _, _ = types.LookupFieldOrMethod(types.NewPointer(recv.Type()), false, pkg, ref.Name)
+		}
+		if fn, ok := obj.(*types.Func); ok {
+			r.funcs[fn] = info
+		}
+	}
+}
+
+// importsZap reports whether the package imports zap, directly or not.
+// Packages that do not cannot call zap loggers, nor wrappers of them.
+func (r *resolver) importsZap() bool {
+	return len(r.zapPkgs) > 0
+}
+
+// inspector returns the inspector of the files of the package analyzed by pass, built on first use.
+// Unlike requiring inspect.Analyzer, this builds it only for the packages importing zap,
+// rather than for every dependency the fact-collecting analyzers run on.
+func (r *resolver) inspector(pass *analysis.Pass) *inspector.Inspector {
+	if r.inspect == nil {
+		r.inspect = inspector.New(pass.Files)
+	}
+	return r.inspect
+}
+
+// ssaPackage returns the SSA form of the package analyzed by pass, built on first use.
+// Unlike requiring buildssa.Analyzer, this builds it only for the packages importing zap that need it.
+func (r *resolver) ssaPackage(pass *analysis.Pass) *buildssa.SSA {
+	if r.ssa == nil {
+		result, _ := buildssa.Analyzer.Run(pass) // Never fails.
+		r.ssa = result.(*buildssa.SSA)
+	}
+	return r.ssa
+}
+
+// lookup returns information about the logging function fn,
+// which is either a zap logger method, a configured wrapper or a detected wrapper.
+func (r *resolver) lookup(fn *types.Func) (logFuncInfo, bool) {
+	info, _, ok := r.lookupWithDepth(fn)
+	return info, ok
+}
+
+// lookupWithDepth is like lookup, and also returns the wrapper depth of fn.
+func (r *resolver) lookupWithDepth(fn *types.Func) (logFuncInfo, int, bool) {
+	fn = fn.Origin()
+	if info, ok := r.funcs[fn]; ok {
+		return info, 0, true
+	}
+	if fact, ok := r.facts[fn]; ok {
+		return fact.Info, fact.Depth, true
+	}
+	return logFuncInfo{}, 0, false
+}
+
+// name returns the canonical full name of fn.
+func (r *resolver) name(fn *types.Func) string {
+	fn = fn.Origin()
+	name, ok := r.names[fn]
+	if !ok {
+		name = r.opts.canonical(fn.FullName())
+		r.names[fn] = name
+	}
+	return name
+}
+
+// zapPkg returns the canonical path of the package of fn if it is a zap package, or "".
+func (r *resolver) zapPkg(fn *types.Func) string {
+	return r.zapPkgs[fn.Pkg()]
+}
+
+// isZapPointer reports whether t is a pointer to one of the named zap types.
+func (r *resolver) isZapPointer(t types.Type, names ...string) bool {
+	ptr, ok := types.Unalias(t).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	if !ok || r.zapPkgs[named.Obj().Pkg()] != zapModule {
+		return false
+	}
+	return slices.Contains(names, named.Obj().Name())
+}
+
+// callee returns the function called by call.
+// Calls on logger interfaces are resolved to the corresponding zap method,
+// and calls on other interfaces to the abstract method (e.g. fmt.Stringer.String).
+func (r *resolver) callee(info *types.Info, call *ast.CallExpr) *types.Func {
+	if fn, ok := r.callees[call]; ok {
+		return fn
+	}
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil {
+		fn = r.ifaces.method(info, call)
+	}
+	if fn == nil {
+		fn, _ = typeutil.Callee(info, call).(*types.Func)
+	}
+	r.callees[call] = fn
+	return fn
+}
+
+// ssaCallee is like callee for SSA calls.
+func (r *resolver) ssaCallee(call *ssa.CallCommon) *types.Func {
+	if call.IsInvoke() {
+		return r.ifaces.resolve(call.Value.Type(), call.Method)
+	}
+	if callee := call.StaticCallee(); callee != nil {
+		fn, _ := callee.Object().(*types.Func)
+		return fn
+	}
+	return nil
+}
+
+// ssaDerives reports whether call statically calls a zap method deriving a new logger from its receiver (e.g. With).
+func (r *resolver) ssaDerives(call *ssa.CallCommon) bool {
+	callee := call.StaticCallee()
+	if callee == nil {
+		return false
+	}
+	fn, ok := callee.Object().(*types.Func)
+	if !ok || r.zapPkg(fn) != zapModule {
+		return false
+	}
+	info, ok := r.funcs[fn.Origin()]
+	return ok && info.Derives && len(call.Args) > 0
+}
+
+// ssaCalleeName returns the canonical full name of the statically known callee, or "".
+func (r *resolver) ssaCalleeName(call *ssa.CallCommon) string {
+	callee := call.StaticCallee()
+	if callee == nil {
+		return ""
+	}
+	if fn, ok := callee.Object().(*types.Func); ok {
+		return r.name(fn)
+	}
+	return ""
+}
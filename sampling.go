@@ -0,0 +1,113 @@
+package zaplint
+
+import (
+	"slices"
+
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// samplingHandler computes whether loggers are sampled, either by their configuration
+// (zap.Config.Sampling, zap.NewProduction) or by a sampler core (zapcore.NewSamplerWithOptions).
+// Loggers whose construction cannot be traced (e.g. parameters) are not.
+type samplingHandler struct {
+	resolver *resolver
+}
+
+func newSamplingTracer(r *resolver, ssainfo *buildssa.SSA) *loggerTracer[bool] {
+	return newLoggerTracer[bool](r, ssainfo, samplingHandler{resolver: r})
+}
+
+func (h samplingHandler) core(v ssa.Value) bool { return isSamplerCore(h.resolver, v) }
+func (samplingHandler) unknown() bool           { return false }
+func (samplingHandler) visiting() bool          { return true }
+
+// preset reports whether the constructor is sampled: the production configuration is.
+func (samplingHandler) preset(name string) bool {
+	return name == zapModule+".NewProduction"
+}
+
+func (h samplingHandler) options(v ssa.Value, base bool) bool {
+	return base || h.samplingOptions(v)
+}
+
+// join reports whether all the loggers are sampled.
+func (samplingHandler) join(values []bool) bool {
+	return len(values) > 0 && !slices.Contains(values, false)
+}
+
+// config reports whether the zap.Config v has a sampling configuration,
+// e.g. because it is zap.NewProductionConfig() or a literal setting Sampling.
+func (h samplingHandler) config(v ssa.Value) bool {
+	switch v := v.(type) {
+	case *ssa.Call:
+		return h.resolver.ssaCalleeName(v.Common()) == zapModule+".NewProductionConfig"
+	case *ssa.UnOp:
+		alloc, ok := v.X.(*ssa.Alloc)
+		if !ok {
+			return false
+		}
+		// The last store wins, in the order of the instructions of the function.
+		sampled := false
+		for _, ref := range *alloc.Referrers() {
+			switch ref := ref.(type) {
+			case *ssa.Store:
+				if ref.Addr == alloc {
+					sampled = h.config(ref.Val)
+				}
+			case *ssa.FieldAddr:
+				if fieldOf(ref).Name() != "Sampling" {
+					continue
+				}
+				for _, fieldRef := range *ref.Referrers() {
+					if store, ok := fieldRef.(*ssa.Store); ok && store.Addr == ref {
+						c, isConst := store.Val.(*ssa.Const)
+						sampled = !isConst || !c.IsNil()
+					}
+				}
+			}
+		}
+		return sampled
+	}
+	return false
+}
+
+// samplingOptions reports whether the zap.Option variadic argument v wraps the core with a sampler,
+// e.g. zap.WrapCore(func(core zapcore.Core) zapcore.Core { return zapcore.NewSamplerWithOptions(core, ...) }).
+func (h samplingHandler) samplingOptions(v ssa.Value) bool {
+	for _, opt := range variadicArgs(v) {
+		call, ok := opt.(*ssa.Call)
+		if !ok || h.resolver.ssaCalleeName(call.Common()) != zapModule+".WrapCore" || len(call.Call.Args) != 1 {
+			continue
+		}
+		var fn *ssa.Function
+		switch f := call.Call.Args[0].(type) {
+		case *ssa.Function:
+			fn = f
+		case *ssa.MakeClosure:
+			fn, _ = f.Fn.(*ssa.Function)
+		}
+		if fn == nil {
+			continue
+		}
+		for _, b := range fn.Blocks {
+			if ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return); ok && len(ret.Results) == 1 && isSamplerCore(h.resolver, ret.Results[0]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSamplerCore reports whether the core v is built by zapcore.NewSamplerWithOptions or zapcore.NewSampler.
+func isSamplerCore(r *resolver, v ssa.Value) bool {
+	call, ok := v.(*ssa.Call)
+	if !ok {
+		return false
+	}
+	switch r.ssaCalleeName(call.Common()) {
+	case zapModule + "/zapcore.NewSamplerWithOptions", zapModule + "/zapcore.NewSampler":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package zaplint
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// stringerCall returns the String method call of expr, if any, i.e. x.String() with x implementing fmt.Stringer.
+func stringerCall(info *types.Info, expr ast.Expr) (*ast.CallExpr, *ast.SelectorExpr) {
+	call, ok := astutil.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, nil
+	}
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return nil, nil
+	}
+	if fn, ok := selection.Obj().(*types.Func); !ok || !isStringMethod(fn) || !isStringer(info.TypeOf(sel.X)) {
+		return nil, nil
+	}
+	return call, sel
+}
+
+// isStringer reports whether t implements fmt.Stringer,
+// which excludes the values whose String method has a pointer receiver.
+func isStringer(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	sel := types.NewMethodSet(t).Lookup(nil, "String")
+	if sel == nil {
+		return false
+	}
+	fn, ok := sel.Obj().(*types.Func)
+	return ok && isStringMethod(fn)
+}
+
+// checkStringerField reports zap.String(key, x.String()), which calls String even if the entry is not logged,
+// and suggests zap.Stringer(key, x).
+func checkStringerField(pass *analysis.Pass, call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 2 {
+		return
+	}
+	inner, innerSel := stringerCall(pass.TypesInfo, call.Args[1])
+	if inner == nil {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     call.Pos(),
+		Message: "zap.Stringer should be used instead of calling String",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Use zap.Stringer",
+			TextEdits: []analysis.TextEdit{
+				{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Stringer")},
+				{Pos: innerSel.X.End(), End: inner.End()},
+			},
+		}},
+	})
+}
+
+// checkStringerValues reports the values of the key-value pairs of sugared loggers calling String,
+// since zap.Any calls it lazily if the value is only a fmt.Stringer.
+func checkStringerValues(pass *analysis.Pass, r *resolver, args []ast.Expr) {
+	for i := 1; i < len(args); i += 2 {
+		inner, innerSel := stringerCall(pass.TypesInfo, args[i])
+		if inner == nil || r.anyConstructor(pass.TypesInfo.TypeOf(innerSel.X)) != "Stringer" {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     inner.Pos(),
+			Message: "String should not be called on sugared values, they are converted with zap.Stringer",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Remove the call to String",
+				TextEdits: []analysis.TextEdit{{Pos: innerSel.X.End(), End: inner.End()}},
+			}},
+		})
+	}
+}
+
+// checkStringersField reports zap.Strings(key, names) where names is only built by a loop
+// appending the String of each element of a slice, and suggests zap.Stringers(key, slice).
+func checkStringersField(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 2 {
+		return
+	}
+	id, ok := astutil.Unparen(call.Args[1]).(*ast.Ident)
+	if !ok {
+		return
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok {
+		return
+	}
+	var body *ast.BlockStmt
+	for i := len(stack) - 1; i >= 0 && body == nil; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+	}
+	if body == nil {
+		return
+	}
+	elems := stringsLoop(pass.TypesInfo, body, v, call.Pos())
+	if elems == nil {
+		return
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, pass.Fset, elems); err != nil {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     call.Pos(),
+		Message: "zap.Stringers should be used instead of calling String on each element",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Use zap.Stringers",
+			TextEdits: []analysis.TextEdit{
+				{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Stringers")},
+				{Pos: call.Args[1].Pos(), End: call.Args[1].End(), NewText: buf.Bytes()},
+			},
+		}},
+	})
+}
+
+// stringsLoop returns the slice ranged over by the loop building the variable v of the body, if v is only
+// defined empty (e.g. var v []string) and assigned by a loop before pos of the form:
+//
+//	for _, elem := range elems {
+//		v = append(v, elem.String())
+//	}
+func stringsLoop(info *types.Info, body *ast.BlockStmt, v *types.Var, pos token.Pos) ast.Expr {
+	var elems ast.Expr
+	valid := true
+	ast.Inspect(body, func(node ast.Node) bool {
+		if !valid {
+			return false
+		}
+		switch node := node.(type) {
+		case *ast.RangeStmt:
+			if x := appendedStrings(info, node, v); x != nil {
+				if elems != nil || node.End() > pos {
+					valid = false
+				}
+				elems = x
+				return false
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if info.Defs[name] == v && len(node.Values) > 0 {
+					valid = len(node.Names) == len(node.Values) && isEmptyStrings(info, node.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if index, ok := lhs.(*ast.IndexExpr); ok {
+					lhs = index.X
+				}
+				if id, ok := lhs.(*ast.Ident); ok && info.ObjectOf(id) == v {
+					valid = node.Tok == token.DEFINE && id == node.Lhs[i] && len(node.Lhs) == len(node.Rhs) && isEmptyStrings(info, node.Rhs[i])
+				}
+			}
+		case *ast.UnaryExpr:
+			if id, ok := node.X.(*ast.Ident); ok && node.Op == token.AND && info.Uses[id] == v {
+				valid = false
+			}
+		}
+		return valid
+	})
+	if !valid {
+		return nil
+	}
+	return elems
+}
+
+// appendedStrings returns the ranged slice if the loop appends the String of each of its elements to v.
+func appendedStrings(info *types.Info, loop *ast.RangeStmt, v *types.Var) ast.Expr {
+	if _, ok := info.TypeOf(loop.X).Underlying().(*types.Slice); !ok || len(loop.Body.List) != 1 {
+		return nil
+	}
+	if key, ok := loop.Key.(*ast.Ident); loop.Key != nil && (!ok || key.Name != "_") {
+		return nil
+	}
+	value, ok := loop.Value.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	assign, ok := loop.Body.List[0].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil
+	}
+	if lhs, ok := assign.Lhs[0].(*ast.Ident); !ok || info.Uses[lhs] != v {
+		return nil
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || call.Ellipsis.IsValid() {
+		return nil
+	}
+	if fn, ok := call.Fun.(*ast.Ident); !ok || info.Uses[fn] != types.Universe.Lookup("append") {
+		return nil
+	}
+	if slice, ok := call.Args[0].(*ast.Ident); !ok || info.Uses[slice] != v {
+		return nil
+	}
+	_, sel := stringerCall(info, call.Args[1])
+	if sel == nil {
+		return nil
+	}
+	if elem, ok := sel.X.(*ast.Ident); !ok || info.Uses[elem] == nil || info.Uses[elem] != info.Defs[value] {
+		return nil
+	}
+	return loop.X
+}
+
+// isEmptyStrings reports whether expr is an empty slice of strings, e.g. make([]string, 0, n) or []string{}.
+func isEmptyStrings(info *types.Info, expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.CompositeLit:
+		return len(expr.Elts) == 0
+	case *ast.CallExpr:
+		fn, ok := expr.Fun.(*ast.Ident)
+		if !ok || info.Uses[fn] != types.Universe.Lookup("make") || len(expr.Args) < 2 {
+			return false
+		}
+		length := info.Types[expr.Args[1]].Value
+		return length != nil && length.String() == "0"
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+package zaplint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// loggerConstructors are functions creating a logger that buffers output and needs to be synced.
+var loggerConstructors = []string{
+	zapModule + ".New",
+	zapModule + ".NewProduction",
+	zapModule + ".NewDevelopment",
+	"(" + zapModule + ".Config).Build",
+}
+
+// checkMissingSync reports loggers created in the main package that are not synced
+// before the function creating them returns.
+func checkMissingSync(pass *analysis.Pass, opts *Options, r *resolver) {
+	if pass.Pkg.Name() != "main" {
+		return
+	}
+	ssainfo := r.ssaPackage(pass)
+	for _, fn := range ssainfo.SrcFuncs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				call, ok := instr.(*ssa.Call)
+				if !ok || !slices.Contains(loggerConstructors, r.ssaCalleeName(call.Common())) {
+					continue
+				}
+				logger := newValueSet(r)
+				if _, ok := call.Type().(*types.Tuple); ok {
+					for _, ref := range *call.Referrers() {
+						if extract, ok := ref.(*ssa.Extract); ok && extract.Index == 0 {
+							logger.add(extract)
+						}
+					}
+				} else {
+					logger.add(call)
+				}
+				if logger.propagate() {
+					// The logger outlives the function, so it is synced elsewhere (if at all).
+					continue
+				}
+				if !isSynced(fn, logger, opts) {
+					pass.Reportf(call.Pos(), "logger should be synced with a deferred Sync call")
+				}
+			}
+		}
+	}
+}
+
+// isSynced reports whether fn defers syncing one of the logger values,
+// either directly, through one of Options.SyncFuncs or inside a deferred closure.
+func isSynced(fn *ssa.Function, logger *valueSet, opts *Options) bool {
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			deferInstr, ok := instr.(*ssa.Defer)
+			if !ok {
+				continue
+			}
+			if isSyncCall(&deferInstr.Call, logger, opts) {
+				return true
+			}
+			closure, ok := deferInstr.Call.Value.(*ssa.MakeClosure)
+			if !ok {
+				continue
+			}
+			body := closure.Fn.(*ssa.Function)
+			captured := newValueSet(logger.resolver)
+			for i, binding := range closure.Bindings {
+				if logger.has(binding) {
+					captured.add(body.FreeVars[i])
+				}
+			}
+			captured.propagate()
+			if callsSync(body, captured, opts) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callsSync reports whether fn syncs one of the logger values.
+func callsSync(fn *ssa.Function, logger *valueSet, opts *Options) bool {
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if call, ok := instr.(ssa.CallInstruction); ok && isSyncCall(call.Common(), logger, opts) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isSyncCall(call *ssa.CallCommon, logger *valueSet, opts *Options) bool {
+	name := logger.resolver.ssaCalleeName(call)
+	switch {
+	case name == "(*"+zapModule+".Logger).Sync" || name == "(*"+zapModule+".SugaredLogger).Sync":
+		return len(call.Args) > 0 && logger.has(call.Args[0])
+	case slices.Contains(opts.SyncFuncs, name):
+		return slices.ContainsFunc(call.Args, logger.has)
+	}
+	return false
+}
+
+// valueSet is a set of SSA values referring to the same logger.
+type valueSet struct {
+	resolver *resolver
+	values   map[ssa.Value]bool
+	queue    []ssa.Value
+}
+
+func newValueSet(r *resolver) *valueSet {
+	return &valueSet{resolver: r, values: make(map[ssa.Value]bool)}
+}
+
+func (s *valueSet) has(v ssa.Value) bool {
+	return s.values[v]
+}
+
+func (s *valueSet) add(v ssa.Value) {
+	if !s.values[v] {
+		s.values[v] = true
+		s.queue = append(s.queue, v)
+	}
+}
+
+// propagate adds the values derived from the ones in the set: copies, loggers derived
+// with With, Named, etc., and local variables holding them.
+// It reports whether one of the values escapes the function by being returned or stored outside of a local variable.
+func (s *valueSet) propagate() (escapes bool) {
+	for len(s.queue) > 0 {
+		v := s.queue[0]
+		s.queue = s.queue[1:]
+		refs := v.Referrers()
+		if refs == nil {
+			continue
+		}
+		for _, ref := range *refs {
+			switch ref := ref.(type) {
+			case *ssa.Call:
+				if s.resolver.ssaDerives(ref.Common()) || s.resolver.ssaCalleeName(ref.Common()) == zapModule+".Must" {
+					if len(ref.Call.Args) > 0 && ref.Call.Args[0] == v {
+						s.add(ref)
+					}
+				}
+			case *ssa.Phi:
+				s.add(ref)
+			case *ssa.ChangeType:
+				s.add(ref)
+			case *ssa.UnOp:
+				if ref.Op == token.MUL {
+					s.add(ref)
+				}
+			case *ssa.Store:
+				if ref.Val != v {
+					continue
+				}
+				if alloc, ok := ref.Addr.(*ssa.Alloc); ok {
+					s.add(alloc)
+				} else {
+					escapes = true
+				}
+			case *ssa.Return:
+				escapes = true
+			}
+		}
+	}
+	return escapes
+}
+
+// buildFuncs are functions returning a logger together with an error.
+var buildFuncs = []string{
+	zapModule + ".NewProduction",
+	zapModule + ".NewDevelopment",
+	"(" + zapModule + ".Config).Build",
+}
+
+// checkIgnoredBuildError reports the statement (*ast.ExprStmt or *ast.AssignStmt)
+// if it ignores the error returned by a logger constructor.
+func checkIgnoredBuildError(pass *analysis.Pass, r *resolver, stmt ast.Stmt) {
+	var call *ast.CallExpr
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		call, _ = astutil.Unparen(stmt.X).(*ast.CallExpr)
+	case *ast.AssignStmt:
+		if len(stmt.Lhs) != 2 || len(stmt.Rhs) != 1 {
+			return
+		}
+		if id, ok := stmt.Lhs[1].(*ast.Ident); !ok || id.Name != "_" {
+			return
+		}
+		call, _ = astutil.Unparen(stmt.Rhs[0]).(*ast.CallExpr)
+	}
+	if call == nil {
+		return
+	}
+	fn := r.callee(pass.TypesInfo, call)
+	if fn == nil || !slices.Contains(buildFuncs, r.name(fn)) {
+		return
+	}
+	pass.Reportf(call.Pos(), "error returned by %s should be checked", fn.Name())
+}
@@ -0,0 +1,15 @@
+package allow_any
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func fields(logger *zap.Logger, name string, d time.Duration) {
+	logger.Info("any",
+		zap.Any("name", name),     // OK
+		zap.Any("elapsed", d),     // OK
+		zap.Reflect("name", name), // OK
+	)
+}
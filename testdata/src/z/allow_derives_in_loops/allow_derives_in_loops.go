@@ -0,0 +1,11 @@
+package allow_derives_in_loops
+
+import (
+	"go.uber.org/zap"
+)
+
+func loop(logger *zap.Logger, ids []string) {
+	for _, id := range ids {
+		logger.With(zap.String("id", id)).Info("request") // OK
+	}
+}
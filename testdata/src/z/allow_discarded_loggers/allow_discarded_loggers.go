@@ -0,0 +1,8 @@
+package allow_discarded_loggers
+
+import "go.uber.org/zap"
+
+func tests(logger *zap.Logger) {
+	logger.With(zap.String("id", "1")) // OK
+	_ = logger.Named("worker")         // OK
+}
@@ -0,0 +1,11 @@
+package allow_expensive_debug_args
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+func debug(logger *zap.Logger, path string) {
+	logger.Debug("request", zap.String("path", fmt.Sprintf("/api/%s", path))) // OK
+}
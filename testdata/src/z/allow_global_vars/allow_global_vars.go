@@ -0,0 +1,11 @@
+package allow_global_vars
+
+import "go.uber.org/zap"
+
+var logger = zap.Must(zap.NewProduction()) // OK
+
+func tests() {
+	logger.Info("msg")                 // OK
+	defer zap.ReplaceGlobals(logger)() // OK
+	defer zap.RedirectStdLog(logger)() // OK
+}
@@ -0,0 +1,23 @@
+package allow_invalid_cores
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+type core struct {
+	zapcore.LevelEnabler
+	fields []zapcore.Field
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	c.fields = append(c.fields, fields...) // OK
+	return c
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry { // OK
+	return ce
+}
+
+func (c *core) Write(zapcore.Entry, []zapcore.Field) error { return nil } // OK
+
+func (c *core) Sync() error { return nil }
@@ -0,0 +1,64 @@
+package allow_marshaler_errors
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type user struct {
+	id      string
+	name    string
+	created time.Time
+	tags    tags
+	admin   bool
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("user_id", u.id)          // OK
+	enc.AddString("UserName", u.name)       // want `keys should be written in snake_case`
+	enc.AddString("password", u.name)       // OK
+	enc.AddTime("created_at", u.created)    // OK
+	enc.AddString("user_id", u.name)        // OK
+	enc.AddArray("tags", u.tags)            // OK
+	_ = enc.AddReflected("meta", u.created) // OK
+	if err := enc.AddObject("tags_obj", u.tags); err != nil {
+		return err
+	}
+	if u.admin {
+		enc.AddBool("role", true) // OK
+	} else {
+		enc.AddString("role", "user") // OK
+	}
+	enc.OpenNamespace("details")
+	enc.AddString("user_id", u.id) // OK
+	enc.AddString("user_id", u.id) // OK
+	return nil
+}
+
+type tags []string
+
+func (t tags) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, tag := range t {
+		enc.AppendString(tag)
+	}
+	enc.AppendObject(t) // OK
+	return nil
+}
+
+func (t tags) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("count", len(t)) // OK
+	return enc.AddArray("values", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		enc.AppendReflected(t) // OK
+		return nil
+	}))
+}
+
+var _ = zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+	enc.AddString("Key", "value") // want `keys should be written in snake_case`
+	return nil
+})
+
+func notMarshaler(enc zapcore.ObjectEncoder) {
+	enc.AddString("Key", "value") // OK
+}
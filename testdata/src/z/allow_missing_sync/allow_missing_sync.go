@@ -0,0 +1,8 @@
+package main
+
+import "go.uber.org/zap"
+
+func main() {
+	logger, _ := zap.NewProduction() // OK
+	logger.Info("msg")
+}
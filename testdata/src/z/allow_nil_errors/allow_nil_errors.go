@@ -0,0 +1,10 @@
+package allow_nil_errors
+
+import "go.uber.org/zap"
+
+func tests(logger *zap.Logger, err error) {
+	logger.Error("msg", zap.Error(nil)) // OK
+	if err == nil {
+		logger.Error("msg", zap.Error(err)) // OK
+	}
+}
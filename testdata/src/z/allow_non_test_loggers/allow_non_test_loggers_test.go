@@ -0,0 +1,11 @@
+package allow_non_test_loggers
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestLoggers(t *testing.T) {
+	_ = zap.NewNop() // OK
+}
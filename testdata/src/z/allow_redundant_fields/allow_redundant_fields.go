@@ -0,0 +1,13 @@
+package allow_redundant_fields
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func log(logger *zap.Logger) {
+	logger.Info("started", zap.Time("time", time.Now()))       // OK
+	logger.Info("started", zap.String("caller", "main.go:10")) // OK
+	logger.Error("failed", zap.Stack("stack"))                 // OK
+}
@@ -0,0 +1,15 @@
+package allow_string_methods
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type id int
+
+func (i id) String() string { return fmt.Sprint(int(i)) }
+
+func fields(logger *zap.Logger, i id) {
+	logger.Info("fields", zap.String("id", i.String())) // OK
+}
@@ -0,0 +1,25 @@
+package allowed_levels
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func tests(logger *zap.Logger, sugar *zap.SugaredLogger, level zapcore.Level) {
+	logger.Debug("msg")  // OK
+	logger.Info("msg")   // OK
+	logger.Warn("msg")   // want `"warn" level should not be used`
+	logger.Error("msg")  // OK
+	logger.DPanic("msg") // want `"dpanic" level should not be used`
+
+	logger.Log(zap.WarnLevel, "msg")       // want `"warn" level should not be used`
+	logger.Log(zapcore.InfoLevel, "msg")   // OK
+	logger.Log(level, "msg")               // OK
+	_ = logger.Check(zap.WarnLevel, "msg") // want `"warn" level should not be used`
+
+	sugar.Warnf("msg %d", 1)           // want `"warn" level should not be used`
+	sugar.Warnw("msg", "key", 1)       // want `"warn" level should not be used`
+	sugar.Warnln("msg")                // want `"warn" level should not be used`
+	sugar.Logw(zap.DPanicLevel, "msg") // want `"dpanic" level should not be used`
+	sugar.Infow("msg", "key", 1)       // OK
+}
@@ -0,0 +1,9 @@
+package hotpath
+
+import "go.uber.org/zap"
+
+func tests(logger *zap.Logger) {
+	logger.Debug("msg") // want `"debug" level should not be used`
+	logger.Info("msg")  // OK
+	logger.Warn("msg")  // want `"warn" level should not be used`
+}
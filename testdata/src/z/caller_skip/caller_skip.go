@@ -0,0 +1,62 @@
+package caller_skip
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type Logger struct{ z *zap.Logger }
+
+func New(z *zap.Logger) *Logger {
+	return &Logger{z: z.WithOptions(zap.AddCallerSkip(1))}
+}
+
+func (l *Logger) Info(msg string, fields ...zap.Field) {
+	l.z.Info(msg, fields...) // OK
+}
+
+type Unskipped struct{ z *zap.Logger }
+
+func NewUnskipped() *Unskipped {
+	return &Unskipped{z: zap.Must(zap.NewProduction())}
+}
+
+func (u *Unskipped) Info(msg string, fields ...zap.Field) {
+	u.z.Info(msg, fields...) // want `wrapper Info should use a logger built with zap.AddCallerSkip\(1\), found 0`
+}
+
+type Nested struct{ z *zap.Logger }
+
+func NewNested(core zapcore.Core) *Nested {
+	return &Nested{z: zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))}
+}
+
+func (n *Nested) Info(msg string, fields ...zap.Field) {
+	n.log(zap.InfoLevel, msg, fields...) // OK
+}
+
+func (n *Nested) Error(msg string, fields ...zap.Field) {
+	n.log(zap.ErrorLevel, msg, fields...) // OK
+}
+
+func (n *Nested) log(lvl zapcore.Level, msg string, fields ...zap.Field) {
+	n.z.Log(lvl, msg, fields...) // want `wrapper log should use a logger built with zap.AddCallerSkip\(2\), found 1`
+}
+
+var global = zap.Must(zap.NewDevelopment(zap.AddCallerSkip(1)))
+
+func Info(msg string, fields ...zap.Field) {
+	global.Info(msg, fields...) // OK
+}
+
+func Sugared(msg string, args ...any) {
+	global.Sugar().Infow(msg, args...) // OK
+}
+
+func Debug(msg string, args ...any) {
+	global.WithOptions(zap.AddCallerSkip(1)).Sugar().Debugw(msg, args...) // want `wrapper Debug should use a logger built with zap.AddCallerSkip\(1\), found 2`
+}
+
+func With(z *zap.Logger, msg string, fields ...zap.Field) {
+	z.Info(msg, fields...) // OK
+}
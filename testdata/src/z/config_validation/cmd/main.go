@@ -0,0 +1,16 @@
+package main
+
+import (
+	"go.uber.org/zap"
+)
+
+func main() {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Development = true // want `Development should not be enabled in production code`
+	logger, err := cfg.Build()
+	if err != nil {
+		panic(err)
+	}
+	defer logger.Sync()
+	logger.Info("started")
+}
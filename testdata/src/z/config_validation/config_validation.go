@@ -0,0 +1,88 @@
+package config_validation
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	_ "z/config_validation/sinks"
+)
+
+func configs() {
+	_ = zap.Config{
+		Level:            zap.NewAtomicLevelAt(zap.InfoLevel),
+		Encoding:         "jsn",                                                                                                             // want `encoding "jsn" is not registered, use "json", "console" or register it with zap.RegisterEncoder`
+		OutputPaths:      []string{"stdout", "/var/log/app.log", "file:///var/log/app.log", "kafka://broker:9092/logs", "http://collector"}, // want `output path "http://collector" has the unregistered scheme "http", register it with zap.RegisterSink`
+		ErrorOutputPaths: []string{"stderr", "file://host/var/log/errors.log"},                                                              // want `output path "file://host/var/log/errors.log" is not a valid file URL: the host should be empty or localhost`
+		Development:      true,                                                                                                              // want `Development should not be enabled in production code`
+		Sampling: &zap.SamplingConfig{
+			Initial:    0, // want `SamplingConfig.Initial should be positive, or the first entries of each second are dropped`
+			Thereafter: 100,
+		},
+	}
+	_ = zap.Config{
+		Level:       zap.NewAtomicLevelAt(zap.InfoLevel),
+		Encoding:    "logfmt", // OK: registered.
+		Development: false,
+		Sampling:    &zap.SamplingConfig{Thereafter: 100}, // want `SamplingConfig.Initial should be positive, or the first entries of each second are dropped`
+	}
+	_ = zap.Config{
+		Level:    zap.NewAtomicLevelAt(zap.InfoLevel),
+		Encoding: "console",
+		EncoderConfig: zapcore.EncoderConfig{ // want `MessageKey should be set with the console encoder, or the messages are omitted`
+			LevelKey:    "level",
+			EncodeLevel: zapcore.LowercaseLevelEncoder,
+		},
+	}
+	_ = zap.Config{
+		Level:    zap.NewAtomicLevelAt(zap.InfoLevel),
+		Encoding: "console",
+		EncoderConfig: zapcore.EncoderConfig{
+			MessageKey: "msg",
+		},
+	}
+
+	cfg := zap.NewProductionConfig()
+	cfg.Encoding = "yaml"                                         // want `encoding "yaml" is not registered, use "json", "console" or register it with zap.RegisterEncoder`
+	cfg.OutputPaths = append(cfg.OutputPaths, "s3://bucket/logs") // want `output path "s3://bucket/logs" has the unregistered scheme "s3", register it with zap.RegisterSink`
+	cfg.Development = true                                        // want `Development should not be enabled in production code`
+	cfg.Sampling.Initial = 0                                      // want `SamplingConfig.Initial should be positive, or the first entries of each second are dropped`
+	cfg.Sampling.Initial = 100
+	cfg.EncoderConfig.MessageKey = "message"
+}
+
+func encoders() {
+	_ = zapcore.EncoderConfig{
+		TimeKey:    "ts",     // want `EncodeTime should be set with TimeKey, or zap.Config.Build fails`
+		LevelKey:   "level",  // want `EncodeLevel should be set with LevelKey, or the level is omitted`
+		CallerKey:  "caller", // want `EncodeCaller should be set with CallerKey, or the JSON encoder panics`
+		MessageKey: "msg",
+	}
+	_ = zapcore.EncoderConfig{
+		TimeKey:      "ts",
+		LevelKey:     "level",
+		CallerKey:    "caller",
+		MessageKey:   "msg",
+		EncodeTime:   zapcore.ISO8601TimeEncoder,
+		EncodeLevel:  zapcore.LowercaseLevelEncoder,
+		EncodeCaller: zapcore.ShortCallerEncoder,
+	}
+	_ = zapcore.NewConsoleEncoder(zapcore.EncoderConfig{}) // want `MessageKey should be set with the console encoder, or the messages are omitted`
+}
+
+func assigned() {
+	ec := zapcore.EncoderConfig{TimeKey: "ts"} // OK: EncodeTime is set below.
+	ec.EncodeTime = zapcore.ISO8601TimeEncoder
+	s := &zap.SamplingConfig{} // OK: Initial is set below.
+	s.Initial = 100
+	var other = zapcore.EncoderConfig{LevelKey: "level"} // want `EncodeLevel should be set with LevelKey, or the level is omitted`
+	other.MessageKey = "msg"
+	cfg := zap.Config{
+		Level:         zap.NewAtomicLevelAt(zap.InfoLevel),
+		Encoding:      "console",
+		EncoderConfig: zapcore.EncoderConfig{}, // OK: MessageKey is set below.
+		Sampling:      &zap.SamplingConfig{},   // OK: Initial is set below.
+	}
+	cfg.EncoderConfig.MessageKey = "msg"
+	cfg.Sampling.Initial = 100
+	_, _, _, _ = ec, s, other, cfg
+}
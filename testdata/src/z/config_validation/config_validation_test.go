@@ -0,0 +1,11 @@
+package config_validation
+
+import (
+	"go.uber.org/zap"
+)
+
+func newTestLogger() (*zap.Logger, error) {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Development = true // OK: test code.
+	return cfg.Build()
+}
@@ -0,0 +1,11 @@
+package main
+
+import "go.uber.org/zap"
+
+func main() {
+	logger := zap.Must(zap.NewDevelopment()) // OK
+	defer logger.Sync()
+	_ = zap.NewNop()              // want `zap.NewNop should not be used in main code`
+	_ = zap.NewAtomicLevel()      // OK: not a constructor.
+	_ = zap.NewProductionConfig() // want `zap.NewProductionConfig should not be used in main code`
+}
@@ -0,0 +1,10 @@
+package constructor_policy
+
+import "go.uber.org/zap"
+
+func newLogger() *zap.Logger {
+	_ = zap.NewDevelopmentConfig()       // want `zap.NewDevelopmentConfig should not be used in production code`
+	_ = zap.NewExample()                 // want `zap.NewExample should not be used in production code`
+	_ = zap.NewNop()                     // want `zap.NewNop should not be used in production code`
+	return zap.Must(zap.NewProduction()) // OK
+}
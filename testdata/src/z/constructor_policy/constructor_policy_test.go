@@ -0,0 +1,24 @@
+package constructor_policy
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestSomething(t *testing.T) {
+	logger := zap.NewNop() // want `zap.NewNop should not be used in test code, use zaptest.NewLogger\(t\) instead`
+	logger.Info("msg")
+	dev, err := zap.NewDevelopment() // want `zap.NewDevelopment should not be used in test code, use zaptest.NewLogger\(t\) instead`
+	if err == nil {
+		dev.Info("msg")
+	}
+	built, err := zap.NewProductionConfig().Build() // want `zap.Config.Build should not be used in tests, use zaptest.NewLogger\(t\) instead`
+	if err == nil {
+		built.Info("msg")
+	}
+}
+
+func helper() {
+	_ = zap.NewNop() // want `zap.NewNop should not be used in test code`
+}
@@ -0,0 +1,118 @@
+package cores
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// kafkaCore is a correct core.
+type kafkaCore struct {
+	zapcore.LevelEnabler
+	enc    zapcore.Encoder
+	fields []zapcore.Field
+}
+
+func (c *kafkaCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(clone.fields[:len(clone.fields):len(clone.fields)], fields...)
+	return &clone
+}
+
+func (c *kafkaCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *kafkaCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, append(c.fields, fields...))
+	if err != nil {
+		return err
+	}
+	buf.Free()
+	return nil
+}
+
+func (c *kafkaCore) Sync() error { return nil }
+
+// otelCore has all the bugs.
+type otelCore struct {
+	level  zapcore.Level
+	other  zapcore.LevelEnabler
+	fields []zapcore.Field
+	count  int
+}
+
+func (c *otelCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+func (c *otelCore) With(fields []zapcore.Field) zapcore.Core {
+	c.fields = append(c.fields, fields...) // want `With should not modify the core it is called on, modify a clone and return it`
+	c.count++                              // want `With should not modify the core it is called on, modify a clone and return it`
+	return c
+}
+
+func (c *otelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry { // want `Check should add the core to the checked entry with ce.AddCore\(ent, c\), or Write is never called`
+	return ce
+}
+
+func (c *otelCore) Write(ent zapcore.Entry, _ []zapcore.Field) error { // want `Write should encode the fields of the entry`
+	return nil
+}
+
+func (c *otelCore) Sync() error { return nil }
+
+// levelCore checks another level than Enabled.
+type levelCore struct {
+	level zapcore.LevelEnabler
+	other zapcore.LevelEnabler
+}
+
+func (c levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+func (c levelCore) With(fields []zapcore.Field) zapcore.Core { return c }
+
+func (c levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry { // want `Check should add the core only if c.Enabled\(ent.Level\), so that it is consistent with Enabled`
+	if c.other.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c levelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error { // want `Write should encode the fields of the entry`
+	return nil
+}
+
+func (c levelCore) Sync() error { return nil }
+
+// delegatingCore checks the level it delegates Enabled to.
+type delegatingCore struct {
+	level zapcore.LevelEnabler
+	zapcore.Core
+}
+
+func (c delegatingCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+func (c delegatingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.level.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// filteringCore delegates Check to the core it wraps, and does not declare Write.
+type filteringCore struct {
+	zapcore.Core
+}
+
+func (c filteringCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Message == "" {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
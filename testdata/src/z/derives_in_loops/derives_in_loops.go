@@ -0,0 +1,56 @@
+package derives_in_loops
+
+import (
+	"go.uber.org/zap"
+)
+
+type server struct {
+	logger *zap.Logger
+}
+
+type request struct {
+	id   string
+	user string
+}
+
+func (s *server) serve(requests []request) {
+	for _, r := range requests {
+		s.logger.With(zap.String("id", r.id)).Info("request") // want `With should not be called inside a loop on the same logger, pass the fields to the logging calls instead`
+		s.logger.Named("server").Info("request")              // want `Named should not be called inside a loop on the same logger, hoist it out of the loop`
+		s.logger.Sugar().Infow("request", "id", r.id)         // want `Sugar should not be called inside a loop, convert the logger once before the loop`
+	}
+}
+
+func loops(logger *zap.Logger, sugar *zap.SugaredLogger, requests []request) {
+	for i := 0; i < len(requests); i++ {
+		logger.WithLazy(zap.String("id", requests[i].id)).Info("request") // want `WithLazy should not be called inside a loop on the same logger, pass the fields to the logging calls instead`
+		logger.WithOptions(zap.AddCallerSkip(1)).Info("request")          // want `WithOptions should not be called inside a loop on the same logger, hoist it out of the loop`
+		sugar.Desugar().Info("request")                                   // want `Desugar should not be called inside a loop, convert the logger once before the loop`
+		sugar.With("id", requests[i].id).Info("request")                  // want `With should not be called inside a loop on the same logger, pass the fields to the logging calls instead`
+	}
+	for range requests {
+		logger.With(zap.String("a", "b")).With(zap.String("c", "d")).Info("request") // want `With should not be called inside a loop on the same logger, hoist it out of the loop`
+	}
+}
+
+func variant(logger *zap.Logger, requests []request) {
+	named := logger.Named("server") // OK: outside of the loop.
+	for _, r := range requests {
+		l := logger.With(zap.String("id", r.id))           // want `With should not be called inside a loop on the same logger, pass the fields to the logging calls instead`
+		l.With(zap.String("user", r.user)).Info("request") // OK: l changes between iterations.
+		named.Info("request")
+	}
+	for _, r := range requests {
+		logger.Named(r.user).Info("request") // want `Named should not be called inside a loop on the same logger$`
+	}
+	current := logger
+	for _, r := range requests {
+		current = current.With(zap.String("id", r.id)) // OK: current changes between iterations.
+	}
+	current.Info("requests")
+	for _, r := range requests {
+		func() {
+			logger.With(zap.String("id", r.id)).Info("request") // OK: not called by the loop.
+		}()
+	}
+}
@@ -0,0 +1,39 @@
+package log
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type Logger struct {
+	logger *zap.Logger
+	sugar  *zap.SugaredLogger
+}
+
+func Ctx(ctx context.Context) *Logger {
+	return &Logger{}
+}
+
+func (l *Logger) Info(msg string, fields ...zap.Field) {
+	l.logger.Info(msg, fields...)
+}
+
+func (l *Logger) Warnw(msg string, keysAndValues ...any) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+// Infoc logs through another wrapper.
+func Infoc(ctx context.Context, msg string, fields ...zap.Field) {
+	Ctx(ctx).Info(msg, fields...)
+}
+
+// Log forwards a constant level.
+func (l *Logger) Debug(msg string, fields ...zap.Field) {
+	l.logger.Log(zap.DebugLevel, msg, fields...)
+}
+
+// Prefixed does not forward its message as is.
+func (l *Logger) Prefixed(msg string, fields ...zap.Field) {
+	l.logger.Info("prefix: "+msg, fields...)
+}
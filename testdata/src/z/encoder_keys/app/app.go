@@ -0,0 +1,27 @@
+package app
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"z/encoder_keys/config"
+)
+
+type request struct {
+	level string
+}
+
+func (r request) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("severity", r.level) // want `"severity" key is reserved by EncoderConfig.LevelKey \(z/encoder_keys/config/config.go:11\)`
+	return nil
+}
+
+func run() {
+	logger := config.NewLogger()
+	logger.Info("started", zap.String("message", "hello"))                // want `"message" key is reserved by EncoderConfig.MessageKey \(z/encoder_keys/config/config.go:10\)`
+	logger.Info("started", zap.String("ts", "now"))                       // want `"ts" key is reserved by EncoderConfig.TimeKey \(z/encoder_keys/config/config.go:9\)`
+	logger.Info("started", zap.Dict("http", zap.String("severity", "1"))) // want `"severity" key is reserved by EncoderConfig.LevelKey \(z/encoder_keys/config/config.go:11\)`
+	logger.Info("started", zap.Object("request", request{}))              // OK
+	logger.Info("started", zap.String("msg", "hello"))                    // OK: the message key is "message".
+	logger.Info("started", zap.String("caller", "main.go:10"))            // OK: the caller is omitted.
+}
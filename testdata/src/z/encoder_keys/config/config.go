@@ -0,0 +1,17 @@
+package config
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func NewLogger() *zap.Logger {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.MessageKey = "message"
+	cfg.LevelKey = "severity"
+	cfg.CallerKey = ""
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(cfg), zapcore.AddSync(nil), zap.InfoLevel)
+	logger := zap.New(core)
+	logger.Info("created", zap.String("message", "hello")) // want `"message" key is reserved by EncoderConfig.MessageKey \(z/encoder_keys/config/config.go:10\)`
+	return logger
+}
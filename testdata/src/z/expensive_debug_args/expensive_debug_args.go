@@ -0,0 +1,61 @@
+package expensive_debug_args
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type id int
+
+func (i id) String() string { return fmt.Sprint(int(i)) }
+
+type request struct {
+	Path string
+}
+
+func dump(r request) string { return r.Path }
+
+func debug(logger *zap.Logger, r request, i id, names []string) {
+	payload, _ := json.Marshal(r)
+	logger.Debug("request",
+		zap.String("payload", string(payload)),               // OK: marshaled before.
+		zap.String("path", fmt.Sprintf("/api/%s", r.Path)),   // want `fmt.Sprintf is called even if the debug level is disabled, use a lazy field \(e.g. zap.Object\) or check the level with logger.Check`
+		zap.String("id", i.String()),                         // want `String is called even if the debug level is disabled, use zap.Stringer or check the level with logger.Check`
+		zap.String("names", strings.Join(names, ",")),        // want `strings.Join is called even if the debug level is disabled, use a lazy field \(e.g. zap.Object\) or check the level with logger.Check`
+		zap.ByteString("request", must(json.Marshal(r))),     // want `json.Marshal is called even if the debug level is disabled, use a lazy field \(e.g. zap.Object\) or check the level with logger.Check`
+		zap.String("dump", dump(r)),                          // want `dump is called even if the debug level is disabled, use a lazy field \(e.g. zap.Object\) or check the level with logger.Check`
+		zap.Stringer("id", i),                                // OK
+		zap.String(fmt.Sprintf("key_%d", i), r.Path),         // OK: keys are not checked.
+		zap.Any("lazy", func() string { return i.String() }), // OK: not called.
+	)
+	logger.Info("request", zap.String("path", fmt.Sprintf("/api/%s", r.Path))) // OK: not debug.
+	logger.Log(zap.DebugLevel, "request", zap.String("id", i.String()))        // want `String is called even if the debug level is disabled, use zap.Stringer or check the level with logger.Check`
+	logger.Sugar().Debugw("request", "id", i.String())                         // want `String is called even if the debug level is disabled, use zap.Stringer or check the level with logger.Check`
+	logger.Sugar().Debugf("request %s", fmt.Sprint(r))                         // want `fmt.Sprint is called even if the debug level is disabled, use a lazy field \(e.g. zap.Object\) or check the level with logger.Check`
+}
+
+func guarded(logger *zap.Logger, r request, i id) {
+	if logger.Core().Enabled(zap.DebugLevel) {
+		logger.Debug("request", zap.String("id", i.String())) // OK
+	}
+	if logger.Level().Enabled(zapcore.DebugLevel) {
+		logger.Debug("request", zap.String("id", i.String())) // OK
+	}
+	if ce := logger.Check(zap.DebugLevel, "request"); ce != nil {
+		ce.Write(zap.String("id", i.String())) // OK
+	}
+	if r.Path != "" {
+		logger.Debug("request", zap.String("id", i.String())) // want `String is called even if the debug level is disabled, use zap.Stringer or check the level with logger.Check`
+	}
+}
+
+func must(b []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,76 @@
+package key_paths
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func namespaces(logger *zap.Logger, body string) {
+	logger.Info("request",
+		zap.String("body", body), // OK
+		zap.Namespace("http"),
+		zap.String("method", "GET"), // OK
+		zap.Namespace("request"),
+		zap.String("body", body), // want `"http.request.body" key is forbidden and should not be used`
+	)
+	logger.Info("request",
+		zap.String("password", body), // want `"password" key is forbidden and should not be used`
+	)
+}
+
+func dicts(logger *zap.Logger, body string) {
+	logger.Info("request",
+		zap.Dict("http",
+			zap.Dict("request",
+				zap.String("method", "GET"), // OK
+				zap.String("body", body),    // want `"http.request.body" key is forbidden and should not be used`
+			),
+			zap.String("body", body), // OK
+		),
+	)
+	fields := []zap.Field{
+		zap.Dict("user", zap.String("password", body)), // want `"password" key is forbidden and should not be used`
+	}
+	logger.Info("user", fields...)
+}
+
+func with(body string) {
+	logger := zap.NewExample()
+	http := logger.With(zap.Namespace("http"))
+	request := http.With(zap.Namespace("request"))
+	request.Info("request", zap.String("body", body))              // want `"http.request.body" key is forbidden and should not be used`
+	http.Named("server").Info("request", zap.String("body", body)) // OK
+
+	prod, err := zap.NewProduction()
+	if err != nil {
+		return
+	}
+	prod.With(zap.Namespace("http"), zap.Namespace("request")).Info("request", zap.String("body", body)) // want `"http.request.body" key is forbidden and should not be used`
+	prod.Sugar().With(zap.Namespace("http"), zap.Namespace("request")).Infow("request", "body", body)    // want `"http.request.body" key is forbidden and should not be used`
+}
+
+func reassigned(loggers []*zap.Logger, body string) {
+	logger := zap.NewExample().With(zap.Namespace("http"), zap.Namespace("request"))
+	for _, logger = range loggers {
+	}
+	logger.Info("request", zap.String("body", body)) // OK: reassigned by the loop.
+}
+
+type request struct {
+	body string
+}
+
+func (r request) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.OpenNamespace("http")
+	enc.OpenNamespace("request")
+	enc.AddString("body", r.body) // want `"http.request.body" key is forbidden and should not be used`
+	return nil
+}
+
+func names(logger *zap.Logger) {
+	logger.Info("request",
+		zap.Namespace(""),        // want `namespace name should not be empty`
+		zap.Namespace("http.v1"), // want `namespace name "http.v1" should not contain dots` `keys should be written in snake_case`
+		zap.Dict("", zap.Skip()), // want `namespace name should not be empty`
+	)
+}
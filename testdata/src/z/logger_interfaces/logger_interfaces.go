@@ -0,0 +1,65 @@
+package logger_interfaces
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Logger is implemented by *zap.Logger.
+type Logger interface {
+	Info(msg string, fields ...zap.Field)
+	Debug(msg string, fields ...zap.Field)
+	With(fields ...zap.Field) *zap.Logger
+}
+
+// Sugar is implemented by *zap.SugaredLogger.
+type Sugar interface {
+	Infow(msg string, keysAndValues ...any)
+	Desugar() *zap.Logger
+}
+
+// Formatter is implemented by *zap.SugaredLogger, but its signatures do not mention zap types
+// and it is not listed in the options.
+type Formatter interface {
+	Infof(template string, args ...any)
+}
+
+// ContextLogger is not implemented by zap loggers, but listed in the options.
+type ContextLogger interface {
+	Info(msg string, fields ...zap.Field)
+	Ctx(ctx context.Context) ContextLogger
+}
+
+// Printer is neither implemented by zap loggers nor listed in the options.
+type Printer interface {
+	Info(msg string, fields ...zap.Field)
+	Print(v ...any)
+}
+
+func tests(logger Logger, sugar Sugar, ctx context.Context, cl ContextLogger, printer Printer, formatter Formatter, id string, err error) {
+	logger.Info("user logged in", zap.String("user_id", id)) // OK
+	logger.Info("User logged in")                            // want `message should be lowercased`
+	logger.Info("msg " + id)                                 // want `message should be a string literal or a constant`
+	logger.Info("msg", zap.String("userID", id))             // want `keys should be written in snake_case`
+	logger.Debug("msg")                                      // want `"debug" level should not be used`
+	logger.With(zap.String("user_id", id))                   // want `result of With should not be discarded`
+
+	sugar.Infow("msg", "user_id", id) // want `sugared logger should not be used`
+	formatter.Infof("msg %s", id)     // OK
+
+	cl.Ctx(ctx).Info("User logged in") // want `message should be lowercased`
+	printer.Info("User logged in")     // OK
+
+	if err == nil {
+		logger.Info("msg", zap.Error(err)) // want `logged error is always nil`
+	}
+}
+
+func logf(logger Logger, msg string, fields ...zap.Field) {
+	logger.Info(msg, fields...) // OK
+}
+
+func wrappers() {
+	logf(zap.NewNop(), "User logged in") // want `message should be lowercased`
+}
@@ -0,0 +1,75 @@
+package hotpath
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type server struct {
+	logger  *zap.Logger
+	sampled *zap.Logger
+}
+
+func newServer(core zapcore.Core) *server {
+	return &server{
+		logger:  zap.New(core),
+		sampled: zap.New(zapcore.NewSamplerWithOptions(core, time.Second, 10, 100)),
+	}
+}
+
+func process(p []byte) error {
+	if len(p) == 0 {
+		return errors.New("empty packet")
+	}
+	return nil
+}
+
+func (s *server) serve(packets [][]byte) {
+	for _, p := range packets {
+		s.logger.Info("packet", zap.Int("size", len(p)))               // want `logging inside a loop should use a sampled logger or be moved out of the loop`
+		s.logger.Debug("packet", zap.Int("size", len(p)))              // want `logging inside a loop should use a sampled logger or be moved out of the loop`
+		s.logger.Sugar().Infow("packet", "size", len(p))               // want `logging inside a loop should use a sampled logger or be moved out of the loop`
+		s.logger.Warn("packet", zap.Int("size", len(p)))               // OK: warn level.
+		s.logger.Log(zap.InfoLevel, "packet", zap.Int("size", len(p))) // want `logging inside a loop should use a sampled logger or be moved out of the loop`
+		s.sampled.Info("packet", zap.Int("size", len(p)))              // OK: sampled.
+		s.sampled.With(zap.String("id", "1")).Info("packet")           // OK: derived from a sampled logger.
+		s.sampled.Sugar().Infow("packet", "size", len(p))              // OK: derived from a sampled logger.
+		if err := process(p); err != nil {
+			s.logger.Info("failed to process packet", zap.Error(err)) // OK: error handling.
+		}
+		if len(p) > 1024 {
+			s.logger.Info("large packet", zap.Int("size", len(p))) // want `logging inside a loop should use a sampled logger or be moved out of the loop`
+		}
+		func() {
+			s.logger.Info("packet") // OK: not called by the loop.
+		}()
+	}
+	s.logger.Info("packets", zap.Int("count", len(packets))) // OK: outside of the loop.
+}
+
+func configs(packets [][]byte) {
+	production, _ := zap.NewProduction()
+	development, _ := zap.NewDevelopment()
+	cfg := zap.Config{
+		Level:    zap.NewAtomicLevelAt(zap.InfoLevel),
+		Encoding: "json",
+		Sampling: &zap.SamplingConfig{Initial: 100, Thereafter: 100},
+	}
+	configured, _ := cfg.Build()
+	unsampled := zap.NewProductionConfig()
+	unsampled.Sampling = nil
+	built, _ := unsampled.Build()
+	wrapped := development.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, time.Second, 10, 100)
+	}))
+	for range packets {
+		production.Info("packet")  // OK: the production configuration is sampled.
+		development.Info("packet") // want `logging inside a loop should use a sampled logger or be moved out of the loop`
+		configured.Info("packet")  // OK: sampled.
+		built.Info("packet")       // want `logging inside a loop should use a sampled logger or be moved out of the loop`
+		wrapped.Info("packet")     // OK: sampled.
+	}
+}
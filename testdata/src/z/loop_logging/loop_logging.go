@@ -0,0 +1,11 @@
+package loop_logging
+
+import (
+	"go.uber.org/zap"
+)
+
+func loop(logger *zap.Logger, packets [][]byte) {
+	for _, p := range packets {
+		logger.Info("packet", zap.Int("size", len(p))) // OK: not a configured package.
+	}
+}
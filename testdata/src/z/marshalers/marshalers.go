@@ -0,0 +1,106 @@
+package marshalers
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type user struct {
+	id      string
+	name    string
+	created time.Time
+	tags    tags
+	admin   bool
+	token   string
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("user_id", u.id)          // OK
+	enc.AddString("UserName", u.name)       // want `keys should be written in snake_case`
+	enc.AddString("password", u.name)       // want `"password" key is forbidden and should not be used`
+	enc.AddTime("created_at", u.created)    // OK
+	enc.AddString("user_id", u.name)        // want `"user_id" key is already added to the object`
+	enc.AddArray("tags", u.tags)            // want `error returned by AddArray should be checked`
+	_ = enc.AddReflected("meta", u.created) // want `error returned by AddReflected should be checked`
+	if err := enc.AddObject("tags_obj", u.tags); err != nil {
+		return err
+	}
+	if u.admin {
+		enc.AddBool("role", true) // OK
+	} else {
+		enc.AddString("role", "user") // OK
+	}
+	if u.admin {
+		enc.OpenNamespace("admin")
+		enc.AddString("token", u.token) // want `"admin.token" key is forbidden and should not be used`
+	} else {
+		enc.AddString("token", u.token) // OK: not in the admin namespace
+	}
+	enc.OpenNamespace("details")
+	enc.AddString("user_id", u.id) // OK
+	enc.AddString("user_id", u.id) // want `"user_id" key is already added to the object`
+	return nil
+}
+
+type session struct {
+	id      string
+	token   string
+	admin   bool
+	verbose bool
+	kind    int
+}
+
+func (s session) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("session_id", s.id)
+	if s.verbose {
+		enc.AddString("session_id", s.id) // want `"session_id" key is already added to the object`
+	}
+	switch s.kind {
+	case 0:
+		enc.AddInt("kind", 0) // OK
+	case 1:
+		enc.AddInt("kind", 1) // OK
+	}
+	enc.AddInt("kind", s.kind) // OK: not added when kind is neither 0 nor 1
+	if s.admin {
+		enc.OpenNamespace("admin")
+	} else {
+		enc.OpenNamespace("admin")
+	}
+	// The namespace stays open after the blocks opening it.
+	enc.AddString("token", s.token) // want `"admin.token" key is forbidden and should not be used`
+	if s.verbose {
+		enc.OpenNamespace("details")
+	}
+	enc.AddString("token", s.token) // OK: in the admin or admin.details namespace
+	enc.AddString("token", s.token) // want `"token" key is already added to the object`
+	return nil
+}
+
+type tags []string
+
+func (t tags) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, tag := range t {
+		enc.AppendString(tag)
+	}
+	enc.AppendObject(t) // want `error returned by AppendObject should be checked`
+	return nil
+}
+
+func (t tags) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("count", len(t)) // OK
+	return enc.AddArray("values", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		enc.AppendReflected(t) // want `error returned by AppendReflected should be checked`
+		return nil
+	}))
+}
+
+var _ = zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+	enc.AddString("Key", "value") // want `keys should be written in snake_case`
+	return nil
+})
+
+func notMarshaler(enc zapcore.ObjectEncoder) {
+	enc.AddString("Key", "value") // OK
+}
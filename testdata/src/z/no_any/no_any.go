@@ -0,0 +1,84 @@
+package no_any
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type user struct {
+	name string
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.name)
+	return nil
+}
+
+type status int
+
+func (s status) String() string { return fmt.Sprint(int(s)) }
+
+type point struct {
+	x, y int
+}
+
+type id int
+
+type users []user
+
+func fields(logger *zap.Logger, name string, names []string, count int64, ptr *int, data []byte, d time.Duration, at time.Time, u user, s status, err error, errs []error, fields []zap.Field) {
+	logger.Info("any",
+		zap.Any("name", name),     // want `zap.Any should not be used for string values, use zap.String instead`
+		zap.Any("names", names),   // want `zap.Any should not be used for \[\]string values, use zap.Strings instead`
+		zap.Any("count", count),   // want `zap.Any should not be used for int64 values, use zap.Int64 instead`
+		zap.Any("ptr", ptr),       // want `zap.Any should not be used for \*int values, use zap.Intp instead`
+		zap.Any("data", data),     // want `zap.Any should not be used for \[\]byte values, use zap.Binary instead`
+		zap.Any("elapsed", d),     // want `zap.Any should not be used for time.Duration values, use zap.Duration instead`
+		zap.Any("at", at),         // want `zap.Any should not be used for time.Time values, use zap.Time instead`
+		zap.Any("user", u),        // want `zap.Any should not be used for user values, use zap.Object instead`
+		zap.Any("status", s),      // want `zap.Any should not be used for status values, use zap.Stringer instead`
+		zap.Any("err", err),       // OK: an error may also be an ObjectMarshaler.
+		zap.Any("errs", errs),     // want `zap.Any should not be used for \[\]error values, use zap.Errors instead`
+		zap.Any("fields", fields), // want `zap.Any should not be used for \[\]zap.Field values, use zap.Dict instead`
+		zap.Any("const", 42),      // want `zap.Any should not be used for int values, use zap.Int instead`
+		zap.Reflect("name", name), // want `zap.Reflect should not be used for string values, use zap.String instead`
+	)
+}
+
+func reflected(logger *zap.Logger, p point, i id, m map[string]int, v any, marshaler zapcore.ObjectMarshaler) {
+	logger.Info("any",
+		zap.Any("point", p),             // OK
+		zap.Any("id", i),                // OK: not matched by the cases of zap.Any.
+		zap.Any("map", m),               // OK
+		zap.Any("value", v),             // OK: depends on the dynamic type.
+		zap.Any("nil", nil),             // OK
+		zap.Any("err", errors.New("x")), // OK: depends on the dynamic type.
+		zap.Any("user", marshaler),      // want `zap.Any should not be used for zapcore.ObjectMarshaler values, use zap.Object instead`
+		zap.Reflect("point", p),         // OK
+	)
+}
+
+func elements(logger *zap.Logger, us []user, named users, statuses []status, points []point) {
+	logger.Info("any",
+		zap.Any("users", us),              // want `zap.Any should not be used for \[\]user values, use zap.Objects instead`
+		zap.Any("users", named),           // want `zap.Any should not be used for users values, use zap.Objects instead`
+		zap.Any("statuses", statuses),     // want `zap.Any should not be used for \[\]status values, use zap.Stringers instead`
+		zap.Reflect("statuses", statuses), // want `zap.Reflect should not be used for \[\]status values, use zap.Stringers instead`
+		zap.Any("points", points),         // OK
+	)
+}
+
+type failure struct{}
+
+func (failure) Error() string { return "failure" }
+
+func concreteErrors(logger *zap.Logger, f failure, fp *failure) {
+	logger.Info("any",
+		zap.Any("failure", f),  // want `zap.Any should not be used for failure values, use zap.NamedError instead`
+		zap.Any("failure", fp), // want `zap.Any should not be used for \*failure values, use zap.NamedError instead`
+	)
+}
@@ -0,0 +1,26 @@
+package no_discarded_loggers
+
+import "go.uber.org/zap"
+
+type service struct {
+	logger *zap.Logger
+}
+
+func tests(logger *zap.Logger, sugar *zap.SugaredLogger, s *service) {
+	logger.With(zap.String("id", "1"))     // want `result of With should not be discarded`
+	logger.WithLazy(zap.String("id", "1")) // want `result of WithLazy should not be discarded`
+	logger.Named("worker")                 // want `result of Named should not be discarded`
+	logger.WithOptions(zap.AddCaller())    // want `result of WithOptions should not be discarded`
+	logger.Sugar()                         // want `result of Sugar should not be discarded`
+	sugar.With("id", "1")                  // want `result of With should not be discarded`
+	sugar.Desugar()                        // want `result of Desugar should not be discarded`
+	s.logger.Named("service")              // want `result of Named should not be discarded`
+	_ = logger.Named("worker")             // want `result of Named should not be discarded`
+	_, n := logger.Named("worker"), 1      // want `result of Named should not be discarded`
+	_ = n
+
+	logger = logger.With(zap.String("id", "1")) // OK
+	child := logger.Named("child")              // OK
+	child.Info("msg")                           // OK
+	logger.Named("worker").Info("msg")          // OK
+}
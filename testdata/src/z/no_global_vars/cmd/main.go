@@ -0,0 +1,10 @@
+package main
+
+import "go.uber.org/zap"
+
+func main() {
+	logger := zap.Must(zap.NewProduction())
+	defer logger.Sync()
+	defer zap.ReplaceGlobals(logger)() // OK
+	defer zap.RedirectStdLog(logger)() // OK
+}
@@ -0,0 +1,34 @@
+package no_global_vars
+
+import (
+	"log"
+
+	"go.uber.org/zap"
+)
+
+var logger = zap.Must(zap.NewProduction()) // want `logger should not be stored in a package-level variable`
+
+var (
+	sugar   *zap.SugaredLogger // want `logger should not be stored in a package-level variable`
+	options []zap.Option       // OK
+)
+
+type service struct {
+	logger *zap.Logger // OK
+}
+
+func tests(l *zap.Logger) {
+	logger.Info("msg")     // want `global logger should not be used`
+	sugar.Info("msg")      // want `global logger should not be used`
+	local := l             // OK
+	local.Info("msg")      // OK
+	_ = service{logger: l} // OK
+
+	undo := zap.ReplaceGlobals(l) // want `zap.ReplaceGlobals should only be called in the main package`
+	defer undo()
+	defer zap.RedirectStdLog(l)()                 // want `zap.RedirectStdLog should only be called in the main package`
+	_, _ = zap.RedirectStdLogAt(l, zap.InfoLevel) // want `zap.RedirectStdLogAt should only be called in the main package`
+	_ = zap.NewStdLog(l)                          // OK
+	_ = log.Default()                             // OK
+	_ = options                                   // OK
+}
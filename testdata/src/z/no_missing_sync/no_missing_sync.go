@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func main() {
+	logger, err := zap.NewProduction() // want `logger should be synced with a deferred Sync call`
+	if err != nil {
+		os.Exit(1)
+	}
+	logger.Info("msg")
+}
+
+func synced() {
+	logger := zap.Must(zap.NewProduction()) // OK
+	defer logger.Sync()
+}
+
+func syncedSugar() {
+	sugar := zap.Must(zap.NewDevelopment()).Sugar() // OK
+	defer sugar.Sync()
+}
+
+func syncedInClosure() {
+	logger := zap.Must(zap.NewProduction()) // OK
+	defer func() {
+		_ = logger.Sync()
+	}()
+}
+
+func syncedReassigned(cfg zap.Config) {
+	logger, err := cfg.Build() // OK
+	if err != nil {
+		return
+	}
+	logger = logger.Named("main")
+	defer func() {
+		_ = logger.Sync()
+	}()
+}
+
+func syncedByHelper(core zapcore.Core) {
+	logger := zap.New(core) // OK
+	defer shutdown(logger)
+}
+
+func notDeferred(core zapcore.Core) {
+	logger := zap.New(core) // want `logger should be synced with a deferred Sync call`
+	_ = logger.Sync()
+}
+
+func returned() (*zap.Logger, error) {
+	return zap.NewProduction() // OK
+}
+
+func shutdown(logger *zap.Logger) {
+	_ = logger.Sync()
+}
+
+func ignoredErrors(cfg zap.Config) {
+	logger, _ := zap.NewProduction() // want `error returned by NewProduction should be checked`
+	defer logger.Sync()
+	zap.NewDevelopment() // want `error returned by NewDevelopment should be checked` `logger should be synced with a deferred Sync call`
+	cfg.Build()          // want `error returned by Build should be checked` `logger should be synced with a deferred Sync call`
+}
@@ -0,0 +1,37 @@
+package no_nil_errors
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+func work() error { return errors.New("failed") }
+
+func tests(logger *zap.Logger, sugar *zap.SugaredLogger) {
+	logger.Error("msg", zap.Error(nil))                  // want `logged error is always nil`
+	logger.Error("msg", zap.NamedError("cause", nil))    // want `logged error is always nil`
+	logger.Error("msg", zap.Errors("causes", nil))       // OK: a nil slice of errors.
+	logger.Error("msg", zap.Error(errors.New("failed"))) // OK
+
+	err := work()
+	logger.Error("msg", zap.Error(err)) // OK
+	if err == nil {
+		logger.Error("msg", zap.Error(err)) // want `logged error is always nil`
+		sugar.Errorw("msg", "error", err)   // want `logged error is always nil`
+		sugar.Errorw("msg", "key", "error") // OK
+	}
+	if err != nil {
+		logger.Error("msg", zap.Error(err)) // OK
+		return
+	}
+	logger.Error("msg", zap.Error(err)) // want `logged error is always nil`
+
+	var zero error
+	sugar.With("error", zero).Error("msg") // want `logged error is always nil`
+
+	var errs []error
+	if errs == nil {
+		logger.Error("msg", zap.Errors("causes", errs)) // OK
+	}
+}
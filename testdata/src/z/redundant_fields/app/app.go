@@ -0,0 +1,18 @@
+package app
+
+import (
+	"go.uber.org/zap"
+
+	_ "z/redundant_fields/config"
+)
+
+func log(logger *zap.Logger, sugar *zap.SugaredLogger) {
+	logger.Info("started", zap.String("severity", "high"))                             // want `"severity" key duplicates the level of the entry \(EncoderConfig.LevelKey\)`
+	logger.With(zap.String("message", "hello")).Info("started")                        // want `"message" key duplicates the message of the entry \(EncoderConfig.MessageKey\)`
+	sugar.Infow("started", "component", "main")                                        // want `"component" key duplicates the logger name of the entry \(EncoderConfig.NameKey\)`
+	logger.Info("started", zap.String("caller", "main.go:10"))                         // OK: the caller is omitted.
+	logger.Info("started", zap.Dict("http", zap.String("severity", "high")))           // OK: nested.
+	logger.With(zap.Namespace("http")).Info("started", zap.String("severity", "high")) // OK: nested.
+	severity := zap.String("severity", "high")                                         // want `"severity" key duplicates the level of the entry \(EncoderConfig.LevelKey\)`
+	logger.Info("started", severity)
+}
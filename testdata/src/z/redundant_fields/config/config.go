@@ -0,0 +1,27 @@
+package config
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newLogger() (*zap.Logger, error) {
+	cfg := zap.NewProductionConfig()
+	cfg.EncoderConfig = zapcore.EncoderConfig{
+		TimeKey:       "time",
+		LevelKey:      "severity",
+		MessageKey:    "message",
+		CallerKey:     "",
+		StacktraceKey: "stacktrace",
+	}
+	cfg.EncoderConfig.NameKey = "component"
+	return cfg.Build()
+}
+
+func log(logger *zap.Logger) {
+	logger.Info("started", zap.String("time", "now"))          // want `"time" key duplicates the time of the entry \(EncoderConfig.TimeKey\)`
+	logger.Info("started", zap.String("severity", "high"))     // want `"severity" key duplicates the level of the entry \(EncoderConfig.LevelKey\)`
+	logger.Info("started", zap.String("component", "server"))  // want `"component" key duplicates the logger name of the entry \(EncoderConfig.NameKey\)`
+	logger.Info("started", zap.String("msg", "hello"))         // OK: the message key is "message".
+	logger.Info("started", zap.String("caller", "main.go:10")) // OK: the caller is omitted.
+}
@@ -0,0 +1,23 @@
+package redundant_fields
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func log(logger *zap.Logger, sugar *zap.SugaredLogger, start time.Time) {
+	logger.Info("started", zap.Time("time", time.Now()))                   // want `time.Now\(\) duplicates the time of the entry`
+	logger.Info("started", zap.Int64("unix", time.Now().UTC().Unix()))     // want `time.Now\(\) duplicates the time of the entry`
+	logger.Info("started", zap.Duration("elapsed", time.Now().Sub(start))) // OK: computed from the time.
+	logger.Info("started", zap.Time("start", start))                       // OK
+	logger.With(zap.Time("start", time.Now())).Info("started")             // OK: recorded once for all the entries.
+	sugar.Infow("started", "time", time.Now())                             // want `time.Now\(\) duplicates the time of the entry`
+
+	logger.Info("started", zap.String("caller", "main.go:10")) // OK: no encoder configuration in the program.
+	logger.Info("started", zap.String("msg", "hello"))         // OK: no encoder configuration in the program.
+
+	err := errors.New("failed")
+	logger.Error("failed", zap.Error(err), zap.Stack("stack")) // OK: the construction of the logger is unknown.
+}
@@ -0,0 +1,36 @@
+package stacks
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func stacks(core zapcore.Core, err error) {
+	production := zap.Must(zap.NewProduction())
+	production.Error("failed", zap.Error(err), zap.Stack("stack")) // want `zap.Stack duplicates the stack trace added to error entries by zap.AddStacktrace`
+	production.Fatal("failed", zap.StackSkip("stack", 1))          // want `zap.StackSkip duplicates the stack trace added to fatal entries by zap.AddStacktrace`
+	production.Log(zap.ErrorLevel, "failed", zap.Stack("stack"))   // want `zap.Stack duplicates the stack trace added to error entries by zap.AddStacktrace`
+	production.Warn("failed", zap.Error(err), zap.Stack("stack"))  // OK: not stack traced in production.
+	production.Named("worker").Error("failed", zap.Stack("stack")) // want `zap.Stack duplicates the stack trace added to error entries by zap.AddStacktrace`
+
+	development, _ := zap.NewDevelopment()
+	development.Error("failed", zap.Stack("stack")) // want `zap.Stack duplicates the stack trace added to error entries by zap.AddStacktrace`
+
+	cfg := zap.NewProductionConfig()
+	built, _ := cfg.Build()
+	built.Error("failed", zap.Stack("stack")) // want `zap.Stack duplicates the stack trace added to error entries by zap.AddStacktrace`
+
+	disabled := zap.NewProductionConfig()
+	disabled.DisableStacktrace = true
+	quiet, _ := disabled.Build()
+	quiet.Error("failed", zap.Stack("stack")) // OK: stack traces are disabled.
+
+	custom := zap.New(core)
+	custom.Error("failed", zap.Stack("stack")) // OK: no zap.AddStacktrace.
+
+	traced := zap.New(core, zap.AddStacktrace(zap.ErrorLevel))
+	traced.Error("failed", zap.Stack("stack")) // want `zap.Stack duplicates the stack trace added to error entries by zap.AddStacktrace`
+
+	fatal := production.WithOptions(zap.AddStacktrace(zap.FatalLevel))
+	fatal.Error("failed", zap.Stack("stack")) // OK: only fatal entries are stack traced.
+}
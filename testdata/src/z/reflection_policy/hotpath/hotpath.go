@@ -0,0 +1,49 @@
+package hotpath
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type point struct {
+	x, y int
+}
+
+func (p point) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return enc.AppendReflected(p) // want `AppendReflected encodes the value with reflection, which is forbidden in this package`
+}
+
+type request struct {
+	headers map[string][]string
+}
+
+func (r request) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return enc.AddReflected("headers", r.headers) // want `AddReflected encodes the value with reflection, which is forbidden in this package`
+}
+
+type id int
+
+func fields(logger *zap.Logger, p point, pp *point, r request, i id, m map[string]int, v any, d time.Duration) {
+	logger.Info("fields",
+		zap.Any("point", p),     // OK: zapcore.ArrayMarshaler.
+		zap.Any("point", pp),    // OK: zapcore.ArrayMarshaler.
+		zap.Any("request", r),   // OK: zapcore.ObjectMarshaler.
+		zap.Any("id", i),        // want `zap.Any encodes id values with reflection, which is forbidden in this package`
+		zap.Any("map", m),       // want `zap.Any encodes map\[string\]int values with reflection, which is forbidden in this package`
+		zap.Any("value", v),     // OK: depends on the dynamic type.
+		zap.Any("elapsed", d),   // OK
+		zap.Any("nil", nil),     // OK
+		zap.Reflect("point", p), // want `zap.Reflect encodes the value with reflection, which is forbidden in this package`
+	)
+}
+
+func sugared(logger *zap.SugaredLogger, m map[string]int, d time.Duration) {
+	logger.Infow("fields",
+		"map", m, // want `sugared logger encodes map\[string\]int values with reflection, which is forbidden in this package`
+		"elapsed", d, // OK
+	)
+	logger.With("map", m).Info("with") // want `sugared logger encodes map\[string\]int values with reflection, which is forbidden in this package`
+	logger.Infof("map: %v", m)         // OK: formatted with fmt.
+}
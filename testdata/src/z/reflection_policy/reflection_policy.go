@@ -0,0 +1,13 @@
+package reflection_policy
+
+import (
+	"go.uber.org/zap"
+)
+
+type point struct {
+	x, y int
+}
+
+func fields(logger *zap.Logger, p point) {
+	logger.Info("point", zap.Any("point", p), zap.Reflect("point", p)) // OK: reflection is allowed.
+}
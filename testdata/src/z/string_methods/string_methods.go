@@ -0,0 +1,79 @@
+package string_methods
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type id int
+
+func (i id) String() string { return fmt.Sprint(int(i)) }
+
+type name struct {
+	first, last string
+}
+
+func (n *name) String() string { return n.first + " " + n.last }
+
+type failure struct{}
+
+func (failure) Error() string  { return "failure" }
+func (failure) String() string { return "failure" }
+
+func fields(logger *zap.Logger, i id, n name, np *name, s fmt.Stringer, f failure) {
+	logger.Info("fields",
+		zap.String("id", i.String()),               // want `zap.Stringer should be used instead of calling String`
+		zap.String("name", np.String()),            // want `zap.Stringer should be used instead of calling String`
+		zap.String("name", (s).String()),           // want `zap.Stringer should be used instead of calling String`
+		zap.String("name", n.String()),             // OK: *name implements fmt.Stringer, not name.
+		zap.String("err", errors.New("x").Error()), // OK
+		zap.Stringer("id", i),                      // OK
+	)
+	logger.Debug("debug", zap.String("id", i.String())) // want `zap.Stringer should be used instead of calling String`
+}
+
+func sugared(logger *zap.SugaredLogger, i id, f failure) {
+	logger.Infow("fields",
+		"id", i.String(), // want `String should not be called on sugared values, they are converted with zap.Stringer`
+		"failure", f.String(), // OK: converted with zap.NamedError.
+	)
+	logger.With("id", i.String()).Info("with") // want `String should not be called on sugared values, they are converted with zap.Stringer`
+	logger.Infof("id: %s", i.String())         // OK
+}
+
+func loops(logger *zap.Logger, ids []id, others []id) {
+	names := make([]string, 0, len(ids))
+	for _, i := range ids {
+		names = append(names, i.String())
+	}
+	logger.Info("ids", zap.Strings("ids", names)) // want `zap.Stringers should be used instead of calling String on each element`
+
+	var all []string
+	for _, i := range others {
+		all = append(all, i.String())
+	}
+	logger.Info("ids", zap.Strings("ids", all)) // want `zap.Stringers should be used instead of calling String on each element`
+
+	prefixed := []string{"first"}
+	for _, i := range ids {
+		prefixed = append(prefixed, i.String())
+	}
+	logger.Info("ids", zap.Strings("ids", prefixed)) // OK: not only the elements.
+
+	twice := []string{}
+	for _, i := range ids {
+		twice = append(twice, i.String())
+	}
+	for _, i := range others {
+		twice = append(twice, i.String())
+	}
+	logger.Info("ids", zap.Strings("ids", twice)) // OK: several slices.
+
+	formatted := []string{}
+	for _, i := range ids {
+		formatted = append(formatted, fmt.Sprint(i))
+	}
+	logger.Info("ids", zap.Strings("ids", formatted)) // OK
+}
@@ -0,0 +1,10 @@
+package test_loggers
+
+import ()
+
+import "testing"
+import "go.uber.org/zap"
+
+func TestEmptyImports(t *testing.T) {
+	_ = zap.NewNop() // want `zap.NewNop should not be used in tests, use zaptest.NewLogger\(t\) instead`
+}
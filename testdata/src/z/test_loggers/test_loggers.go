@@ -0,0 +1,7 @@
+package test_loggers
+
+import "go.uber.org/zap"
+
+func newLogger() *zap.Logger {
+	return zap.NewNop() // OK
+}
@@ -0,0 +1,31 @@
+package test_loggers
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLoggers(t *testing.T) {
+	_ = zaptest.NewLogger(t) // OK
+	core, _ := observer.New(zap.InfoLevel)
+	_ = zap.New(core) // OK
+
+	_ = zap.NewNop()                                     // want `zap.NewNop should not be used in tests, use zaptest.NewLogger\(t\) instead`
+	_ = zap.NewExample()                                 // want `zap.NewExample should not be used in tests, use zaptest.NewLogger\(t\) instead`
+	_ = zap.Must(zap.NewProduction())                    // want `zap.NewProduction should not be used in tests, use zaptest.NewLogger\(t\) instead`
+	_ = zap.Must(zap.NewDevelopmentConfig().Build())     // want `zap.Config.Build should not be used in tests, use zaptest.NewLogger\(t\) instead`
+	if logger, err := zap.NewDevelopment(); err == nil { // want `zap.NewDevelopment should not be used in tests, use zaptest.NewLogger\(t\) instead`
+		logger.Info("msg")
+	}
+}
+
+func BenchmarkLoggers(b *testing.B) {
+	_ = zap.NewNop() // want `zap.NewNop should not be used in tests, use zaptest.NewLogger\(b\) instead`
+}
+
+func helper() *zap.Logger {
+	return zap.NewNop() // OK
+}
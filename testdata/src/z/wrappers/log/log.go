@@ -0,0 +1,27 @@
+package log
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type Logger struct {
+	logger *zap.Logger
+}
+
+func Ctx(ctx context.Context) *Logger {
+	return &Logger{logger: zap.L()}
+}
+
+func (l *Logger) Info(msg string, fields ...zap.Field) {
+	l.logger.Info(msg, fields...)
+}
+
+func (l *Logger) Warnw(msg string, keysAndValues ...any) {
+	l.logger.Sugar().Warnw(msg, keysAndValues...)
+}
+
+func Debugf(ctx context.Context, template string, args ...any) {
+	Ctx(ctx).logger.Sugar().Debugf(template, args...)
+}
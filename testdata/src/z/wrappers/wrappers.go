@@ -0,0 +1,22 @@
+package wrappers
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"z/wrappers/log"
+)
+
+func tests(ctx context.Context, id string) {
+	log.Ctx(ctx).Info("user logged in", zap.String("user_id", id)) // OK
+	log.Ctx(ctx).Info("User logged in")                            // want `message should be lowercased`
+	log.Ctx(ctx).Info("msg " + id)                                 // want `message should be a string literal or a constant`
+	log.Ctx(ctx).Info("msg", zap.String("userID", id))             // want `keys should be written in snake_case`
+
+	log.Ctx(ctx).Warnw("msg", "user_id", id) // want `"warn" level should not be used`
+	log.Ctx(ctx).Warnw("msg", "userID", id)  // want `"warn" level should not be used` `keys should be written in snake_case`
+
+	log.Debugf(ctx, "user %s logged in", id) // OK
+	log.Debugf(ctx, "User %s logged in", id) // want `message should be lowercased`
+}
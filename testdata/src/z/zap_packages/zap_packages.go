@@ -0,0 +1,18 @@
+package zap_packages
+
+import (
+	zap "z/zapfork"
+)
+
+func tests(logger *zap.Logger, id string) {
+	logger.Info("user logged in", zap.String("user_id", id)) // OK
+	logger.Info("User logged in")                            // want `message should be lowercased`
+	logger.Info("msg " + id)                                 // want `message should be a string literal or a constant`
+	logger.Info("msg", zap.String("userID", id))             // want `keys should be written in snake_case`
+	zap.L().Info("msg")                                      // want `global logger should not be used`
+	logger.Sugar().Infow("msg")                              // want `sugared logger should not be used`
+	logger.With(zap.String("user_id", id))                   // want `result of With should not be discarded`
+
+	field := zap.String("userID", id) // want `keys should be written in snake_case`
+	logger.Info("msg", field)
+}
@@ -0,0 +1,25 @@
+// Package zap is a minimal fork of go.uber.org/zap published under another module path.
+package zap
+
+type Field struct {
+	Key    string
+	String string
+}
+
+func String(key, val string) Field { return Field{Key: key, String: val} }
+
+type Logger struct{}
+
+func NewNop() *Logger { return &Logger{} }
+
+func L() *Logger { return &Logger{} }
+
+func (l *Logger) Info(msg string, fields ...Field) {}
+
+func (l *Logger) With(fields ...Field) *Logger { return l }
+
+func (l *Logger) Sugar() *SugaredLogger { return &SugaredLogger{} }
+
+type SugaredLogger struct{}
+
+func (s *SugaredLogger) Infow(msg string, keysAndValues ...any) {}
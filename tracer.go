@@ -0,0 +1,157 @@
+package zaplint
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// loggerTracer traces loggers back to their construction to compute a property of type T,
+// e.g. whether they are sampled. The constructors and options are interpreted by its handler.
+type loggerTracer[T any] struct {
+	resolver *resolver
+	stores   *stores
+	handler  constructionHandler[T]
+	visiting map[ssa.Value]bool
+}
+
+// constructionHandler computes the property traced by a loggerTracer from the construction of loggers.
+type constructionHandler[T any] interface {
+	// core returns the property of the loggers built by zap.New with the core v.
+	core(v ssa.Value) T
+	// config returns the property of the loggers built by zap.Config.Build with the configuration v.
+	config(v ssa.Value) T
+	// preset returns the property of the loggers built by the zap constructor with the given canonical full name
+	// (zap.NewProduction, zap.NewDevelopment or zap.NewExample).
+	preset(name string) T
+	// options returns the property of the loggers built with the zap.Option variadic argument v
+	// from loggers with the property base.
+	options(v ssa.Value, base T) T
+	// join returns the property of a logger that may be any of loggers with the given properties, possibly none.
+	join(values []T) T
+	// unknown returns the property of the loggers whose construction cannot be traced (e.g. parameters).
+	unknown() T
+	// visiting returns the property of a logger being traced, which is decided by the other paths.
+	visiting() T
+}
+
+func newLoggerTracer[T any](r *resolver, ssainfo *buildssa.SSA, handler constructionHandler[T]) *loggerTracer[T] {
+	// Package-level variables are initialized in the synthetic init function, which is not a source function.
+	funcs := append(slices.Clip(ssainfo.SrcFuncs), ssainfo.Pkg.Func("init"))
+	return &loggerTracer[T]{
+		resolver: r,
+		stores:   newStores(funcs),
+		handler:  handler,
+		visiting: make(map[ssa.Value]bool),
+	}
+}
+
+// trace returns the property of the logger v, whatever its construction.
+func (t *loggerTracer[T]) trace(v ssa.Value) T {
+	h := t.handler
+	if t.visiting[v] {
+		return h.visiting()
+	}
+	t.visiting[v] = true
+	defer delete(t.visiting, v)
+
+	switch v := v.(type) {
+	case *ssa.Call:
+		args := v.Call.Args
+		switch name := t.resolver.ssaCalleeName(v.Common()); name {
+		case zapModule + ".New":
+			if len(args) == 2 {
+				return h.options(args[1], h.core(args[0]))
+			}
+		case "(" + zapModule + ".Config).Build":
+			if len(args) == 2 {
+				return h.options(args[1], h.config(args[0]))
+			}
+		case "(*" + zapModule + ".Logger).WithOptions", "(*" + zapModule + ".SugaredLogger).WithOptions":
+			if len(args) == 2 {
+				return h.options(args[1], t.trace(args[0]))
+			}
+		case zapModule + ".NewProduction", zapModule + ".NewDevelopment", zapModule + ".NewExample":
+			if len(args) == 1 {
+				return h.options(args[0], h.preset(name))
+			}
+		case zapModule + ".Must":
+			if len(args) > 0 {
+				return t.trace(args[0])
+			}
+		default:
+			if t.resolver.ssaDerives(v.Common()) {
+				return t.trace(args[0])
+			}
+		}
+	case *ssa.Extract:
+		return t.trace(v.Tuple)
+	case *ssa.Phi:
+		return t.traceAll(v.Edges)
+	case *ssa.UnOp:
+		return t.traceAll(t.stores.loaded(v))
+	}
+	return h.unknown()
+}
+
+// traceAll returns the property of a logger that may be any of the loggers values.
+func (t *loggerTracer[T]) traceAll(values []ssa.Value) T {
+	properties := make([]T, len(values))
+	for i, value := range values {
+		properties[i] = t.trace(value)
+	}
+	return t.handler.join(properties)
+}
+
+// stores indexes the values stored in struct fields and package-level variables,
+// to trace the loggers loaded from them.
+type stores struct {
+	fields  map[*types.Var][]ssa.Value
+	globals map[*ssa.Global][]ssa.Value
+}
+
+func newStores(funcs []*ssa.Function) *stores {
+	s := &stores{
+		fields:  make(map[*types.Var][]ssa.Value),
+		globals: make(map[*ssa.Global][]ssa.Value),
+	}
+	for _, fn := range funcs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				store, ok := instr.(*ssa.Store)
+				if !ok {
+					continue
+				}
+				switch addr := store.Addr.(type) {
+				case *ssa.FieldAddr:
+					s.fields[fieldOf(addr)] = append(s.fields[fieldOf(addr)], store.Val)
+				case *ssa.Global:
+					s.globals[addr] = append(s.globals[addr], store.Val)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// loaded returns the values that may be loaded by load, if it loads a field or a package-level variable.
+func (s *stores) loaded(load *ssa.UnOp) []ssa.Value {
+	if load.Op != token.MUL {
+		return nil
+	}
+	switch addr := load.X.(type) {
+	case *ssa.FieldAddr:
+		return s.fields[fieldOf(addr)]
+	case *ssa.Global:
+		return s.globals[addr]
+	}
+	return nil
+}
+
+func fieldOf(addr *ssa.FieldAddr) *types.Var {
+	ptr := addr.X.Type().Underlying().(*types.Pointer)
+	return ptr.Elem().Underlying().(*types.Struct).Field(addr.Field)
+}
@@ -0,0 +1,164 @@
+package zaplint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// wrapperFact is exported for functions forwarding their message and arguments to a zap logger,
+// so that calls to them are checked like calls to the logger.
+type wrapperFact struct {
+	Info  logFuncInfo
+	Depth int // The number of function calls between a call to the wrapper and the zap logger.
+}
+
+func (*wrapperFact) AFact() {}
+
+func (f *wrapperFact) String() string {
+	return fmt.Sprintf("zap wrapper(msg: %d, args: %d, depth: %d)", f.Info.MsgPos, f.Info.ArgsStart, f.Depth)
+}
+
+// wrapper is a function of the analyzed package detected as a wrapper.
+type wrapper struct {
+	fn     *types.Func
+	decl   *ast.FuncDecl
+	call   *ast.CallExpr // The call forwarding the message and arguments.
+	callee *types.Func   // The function called by call.
+	fact   *wrapperFact
+}
+
+// wrappers is the result of the wrappers analyzer.
+type wrappers struct {
+	resolver *resolver                  // Resolver of the analyzed package, aware of the detected wrappers.
+	local    []*wrapper                 // Wrappers declared in the analyzed package.
+	calls    map[*ast.CallExpr]*wrapper // Forwarding calls of the local wrappers.
+}
+
+// newWrappersAnalyzer creates the analyzer detecting functions wrapping zap loggers.
+func newWrappersAnalyzer(opts *Options) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:       "zaplintwrappers",
+		Doc:        "detect functions wrapping go.uber.org/zap loggers",
+		FactTypes:  []analysis.Fact{new(wrapperFact)},
+		ResultType: reflect.TypeFor[*wrappers](),
+		Run: func(pass *analysis.Pass) (any, error) {
+			return detectWrappers(pass, opts), nil
+		},
+	}
+}
+
+// detectWrappers detects functions whose string parameter is passed as the message of a logging call,
+// and whose variadic parameter is passed as its arguments (zap.Field or key-value pairs).
+func detectWrappers(pass *analysis.Pass, opts *Options) *wrappers {
+	r := newResolver(pass.Pkg, opts)
+	result := &wrappers{resolver: r, calls: make(map[*ast.CallExpr]*wrapper)}
+	if !r.importsZap() || opts.isZapPkg(pass.Pkg.Path()) {
+		return result
+	}
+	for _, fact := range pass.AllObjectFacts() {
+		if fn, ok := fact.Object.(*types.Func); ok {
+			r.facts[fn] = fact.Fact.(*wrapperFact)
+		}
+	}
+	// Functions are only declared at the top level, so the files are not inspected further.
+	var decls []*ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
+				decls = append(decls, decl)
+			}
+		}
+	}
+	// Wrappers may call other wrappers of the same package, so iterate until no new one is found.
+	for found := true; found; {
+		found = false
+		for _, decl := range decls {
+			fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok || hasWrapper(result.local, fn) {
+				continue
+			}
+			if w := detectWrapper(pass, r, fn, decl); w != nil {
+				pass.ExportObjectFact(fn, w.fact)
+				r.facts[fn] = w.fact
+				result.local = append(result.local, w)
+				result.calls[w.call] = w
+				found = true
+			}
+		}
+	}
+	return result
+}
+
+func hasWrapper(ws []*wrapper, fn *types.Func) bool {
+	for _, w := range ws {
+		if w.fn == fn {
+			return true
+		}
+	}
+	return false
+}
+
+func detectWrapper(pass *analysis.Pass, r *resolver, fn *types.Func, decl *ast.FuncDecl) *wrapper {
+	sig := fn.Type().(*types.Signature)
+	if !sig.Variadic() {
+		return nil
+	}
+	params := sig.Params()
+	paramIndex := func(expr ast.Expr) int {
+		id, ok := expr.(*ast.Ident)
+		if !ok {
+			return -1
+		}
+		obj := pass.TypesInfo.Uses[id]
+		for i := range params.Len() {
+			if params.At(i) == obj {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var found *wrapper
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		if _, ok := node.(*ast.FuncLit); ok {
+			return false
+		}
+		call, ok := node.(*ast.CallExpr)
+		if !ok || !call.Ellipsis.IsValid() {
+			return true
+		}
+		callee := r.callee(pass.TypesInfo, call)
+		if callee == nil || callee == fn {
+			return true
+		}
+		info, depth, ok := r.lookupWithDepth(callee)
+		if !ok || !info.HasMsg || info.NoArgs || len(call.Args) <= info.MsgPos || info.ArgsStart != len(call.Args)-1 {
+			return true
+		}
+		msgIndex := paramIndex(call.Args[info.MsgPos])
+		if msgIndex == -1 || !types.Identical(params.At(msgIndex).Type(), types.Typ[types.String]) {
+			return true
+		}
+		if paramIndex(call.Args[len(call.Args)-1]) != params.Len()-1 {
+			return true
+		}
+		wrapperInfo := info
+		wrapperInfo.MsgPos = msgIndex
+		wrapperInfo.ArgsStart = params.Len() - 1
+		wrapperInfo.HasLevelArg = false
+		wrapperInfo.IsWrapper = true
+		if level, ok := logLevel(pass.TypesInfo, call, info); ok {
+			wrapperInfo.Level = level
+		}
+		found = &wrapper{fn: fn, decl: decl, call: call, callee: callee, fact: &wrapperFact{Info: wrapperInfo, Depth: depth + 1}}
+		return false
+	})
+	return found
+}
@@ -6,9 +6,11 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
 	"iter"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -16,21 +18,70 @@ import (
 
 	"github.com/ettle/strcase"
 	"golang.org/x/tools/go/analysis"
-	"golang.org/x/tools/go/analysis/passes/inspect"
-	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/go/types/typeutil"
 )
 
 // Options are options for the zaplint analyzer.
 type Options struct {
-	AllowGlobal         bool     `json:"allow-global"`            // Allow using global loggers (zap.L() and zap.S()). Default: false (disallowed).
-	AllowSugar          bool     `json:"allow-sugar"`             // Allow using the sugared logger. Default: false (disallowed).
-	AllowDynamicMsg     bool     `json:"allow-dynamic-msg"`       // Allow dynamic log messages. Default: false (disallowed).
-	MsgStyle            string   `json:"msg-style"`               // Enforce message style ("lowercased" or "capitalized"). Default: "lowercased".
-	AllowRawKeys        bool     `json:"allow-raw-keys"`          // Allow using raw string keys instead of constants. Default: false (disallowed).
-	KeyNamingCase       string   `json:"key-naming-case"`         // Enforce key naming convention ("snake", "kebab", "camel", or "pascal"). Default: "snake".
-	ForbiddenKeys       []string `json:"forbidden-keys"`          // Enforce not using specific keys. Default: [].
-	AllowArgsOnSameLine bool     `json:"allow-args-on-same-line"` // Allow putting arguments on the same line. Default: false (disallowed).
+	AllowGlobal               bool              `json:"allow-global"`                 // Allow using global loggers (zap.L() and zap.S()). Default: false (disallowed).
+	AllowGlobalVars           bool              `json:"allow-global-vars"`            // Allow storing loggers in package-level variables. Default: false (disallowed).
+	AllowReplaceGlobals       bool              `json:"allow-replace-globals"`        // Allow calling zap.ReplaceGlobals outside of the main package. Default: false (disallowed).
+	AllowRedirectStdLog       bool              `json:"allow-redirect-std-log"`       // Allow calling zap.RedirectStdLog(At) outside of the main package. Default: false (disallowed).
+	AllowSugar                bool              `json:"allow-sugar"`                  // Allow using the sugared logger. Default: false (disallowed).
+	AllowDynamicMsg           bool              `json:"allow-dynamic-msg"`            // Allow dynamic log messages. Default: false (disallowed).
+	MsgStyle                  string            `json:"msg-style"`                    // Enforce message style ("lowercased" or "capitalized"). Default: "lowercased".
+	AllowRawKeys              bool              `json:"allow-raw-keys"`               // Allow using raw string keys instead of constants. Default: false (disallowed).
+	KeyNamingCase             string            `json:"key-naming-case"`              // Enforce key naming convention ("snake", "kebab", "camel", or "pascal"). Default: "snake".
+	ForbiddenKeys             []string          `json:"forbidden-keys"`               // Enforce not using specific keys. Default: [].
+	AllowArgsOnSameLine       bool              `json:"allow-args-on-same-line"`      // Allow putting arguments on the same line. Default: false (disallowed).
+	AllowedLevels             []string          `json:"allowed-levels"`               // Enforce using only specific levels ("debug", "info", "warn", "error", "dpanic", "panic", "fatal"). Default: [] (all allowed).
+	AllowNilErrors            bool              `json:"allow-nil-errors"`             // Allow logging errors that are provably nil. Default: false (disallowed).
+	AllowDiscardedLoggers     bool              `json:"allow-discarded-loggers"`      // Allow discarding loggers returned by With, Named, etc. Default: false (disallowed).
+	AllowMissingSync          bool              `json:"allow-missing-sync"`           // Allow not syncing loggers created in the main package. Default: false (disallowed).
+	SyncFuncs                 []string          `json:"sync-funcs"`                   // Full names of functions syncing the loggers passed to them (e.g. "example.com/app.Shutdown"). Default: [].
+	AllowIgnoredBuildErrors   bool              `json:"allow-ignored-build-errors"`   // Allow ignoring errors returned by zap.NewProduction, zap.Config.Build, etc. Default: false (disallowed).
+	ConstructorPolicy         ConstructorPolicy `json:"constructor-policy"`           // Allow or deny zap constructors per class of files (production, test, main). Default: {} (all allowed).
+	AllowNonTestLoggers       bool              `json:"allow-non-test-loggers"`       // Allow creating loggers in tests without zaptest or observer. Default: false (disallowed).
+	Wrappers                  []Wrapper         `json:"wrappers"`                     // User-defined functions wrapping zap loggers, checked like zap loggers. Default: [].
+	AllowCallerSkipMismatch   bool              `json:"allow-caller-skip-mismatch"`   // Allow wrappers using loggers whose zap.AddCallerSkip does not match the wrapper depth. Default: false (disallowed).
+	LoggerInterfaces          []string          `json:"logger-interfaces"`            // Full names of interfaces whose methods matching zap methods are checked, in addition to interfaces implemented by zap loggers (e.g. "example.com/log.Logger"). Default: [].
+	ZapPackages               []string          `json:"zap-packages"`                 // Module paths of zap forks checked like go.uber.org/zap (e.g. "example.com/zap"). Default: [].
+	AllowDuplicateKeys        bool              `json:"allow-duplicate-keys"`         // Allow adding the same key twice to an object in MarshalLogObject. Default: false (disallowed).
+	AllowIgnoredEncoderErrors bool              `json:"allow-ignored-encoder-errors"` // Allow ignoring errors returned by AddObject, AddArray, AddReflected, etc. in marshalers. Default: false (disallowed).
+	AllowAny                  bool              `json:"allow-any"`                    // Allow zap.Any and zap.Reflect for values zap has a specific constructor for (e.g. zap.String). Default: false (disallowed).
+	ReflectionPolicy          string            `json:"reflection-policy"`            // Allow or forbid fields encoded with reflection: zap.Reflect, zap.Any and sugared values of types without a specific constructor ("allow" or "forbid"). Default: "allow".
+	AllowExpensiveDebugArgs   bool              `json:"allow-expensive-debug-args"`   // Allow calling expensive functions (fmt.Sprintf, json.Marshal, String methods, etc.) in the arguments of debug logging calls. Default: false (disallowed).
+	ExpensiveFuncs            []string          `json:"expensive-funcs"`              // Full names of functions reported in the arguments of debug logging calls, in addition to the default ones (e.g. "example.com/app.Dump"). Default: [].
+	AllowStringMethods        bool              `json:"allow-string-methods"`         // Allow zap.String(key, x.String()) and the like instead of zap.Stringer(key, x). Default: false (disallowed).
+	AllowDerivesInLoops       bool              `json:"allow-derives-in-loops"`       // Allow calling With, WithLazy, Named, WithOptions, Sugar and Desugar inside loops on a logger not changing between iterations. Default: false (disallowed).
+	LoopLoggingPackages       []string          `json:"loop-logging-packages"`        // Package path patterns (e.g. "internal/hotpath/...") where logging at the info level or lower inside loops is reported, unless the logger is sampled. Default: [].
+	AllowRedundantFields      bool              `json:"allow-redundant-fields"`       // Allow fields duplicating the metadata of the entry: time.Now() values, stack traces at the error level, and keys of the encoder configuration (e.g. "caller"). Default: false (disallowed).
+	ForbidEncoderKeys         bool              `json:"forbid-encoder-keys"`          // Forbid the keys set by the zapcore.EncoderConfig values of the program (e.g. MessageKey: "message") as field keys, like Options.ForbiddenKeys. Default: false.
+	ConfigValidation          bool              `json:"config-validation"`            // Validate the zap.Config, zap.SamplingConfig and zapcore.EncoderConfig literals and field assignments against the values zap accepts, including the sinks and encoders registered by the program. Default: false.
+	AllowInvalidCores         bool              `json:"allow-invalid-cores"`          // Allow zapcore.Core implementations whose Check does not add the core or is inconsistent with Enabled, whose With modifies the core, or whose Write ignores the fields. Default: false (disallowed).
+	Overrides                 []Override        `json:"overrides"`                    // Override options for specific packages. Default: [].
+
+	funcs         map[string]map[funcRef]logFuncInfo // zapFuncs extended with the wrappers, by package path.
+	wrappers      *analysis.Analyzer                 // Analyzer detecting wrappers automatically.
+	encoderKeys   *analysis.Analyzer                 // Analyzer collecting the keys of the encoder configurations.
+	registrations *analysis.Analyzer                 // Analyzer collecting the registered sinks and encoders.
+}
+
+// Override overrides options for packages matching one of its paths.
+// When several overrides match a package, the last one setting an option wins.
+type Override struct {
+	Paths            []string `json:"paths"`             // Package path patterns (e.g. "internal/hotpath/..."). A trailing "/..." also matches subpackages.
+	AllowedLevels    []string `json:"allowed-levels"`    // Overrides Options.AllowedLevels.
+	ReflectionPolicy string   `json:"reflection-policy"` // Overrides Options.ReflectionPolicy.
+}
+
+// Wrapper describes a user-defined function or method wrapping a zap logger.
+// Calls to it are checked like calls to the zap logger methods with the same style.
+type Wrapper struct {
+	Func      string `json:"func"`       // Full name (e.g. "(*example.com/log.Logger).Info" or "example.com/log.Info").
+	Style     string `json:"style"`      // Style of the arguments ("structured" for zap.Field, "sugared" for printf-style or "key-value"). Default: "structured".
+	MsgPos    int    `json:"msg-pos"`    // Position of the message argument. Ignored if not before ArgsStart.
+	ArgsStart int    `json:"args-start"` // Position of the first field, format or key-value argument.
+	Level     string `json:"level"`      // Level of the log entry ("debug", "info", etc.), if fixed by the function. Default: "".
 }
 
 // New creates a new zaplint analyzer.
@@ -41,12 +92,16 @@ func New(opts *Options) *analysis.Analyzer {
 
 	// Apply defaults for string fields
 	applyDefaults(opts)
+	opts.funcs = logFuncs(opts.Wrappers)
+	opts.wrappers = newWrappersAnalyzer(opts)
+	opts.encoderKeys = newEncoderKeysAnalyzer(opts)
+	opts.registrations = newRegistrationsAnalyzer(opts)
 
 	return &analysis.Analyzer{
 		Name:     "zaplint",
 		Doc:      "ensure consistent code style when using go.uber.org/zap",
 		Flags:    *flags(opts),
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Requires: []*analysis.Analyzer{opts.wrappers, opts.encoderKeys, opts.registrations},
 		Run: func(pass *analysis.Pass) (any, error) {
 			if err := validateOptions(opts); err != nil {
 				return nil, err
@@ -70,83 +125,248 @@ func applyDefaults(opts *Options) {
 	if opts.KeyNamingCase == "" {
 		opts.KeyNamingCase = snakeCase
 	}
+
+	// ReflectionPolicy defaults to "allow"
+	if opts.ReflectionPolicy == "" {
+		opts.ReflectionPolicy = reflectionAllow
+	}
 }
 
 type logFuncInfo struct {
-	IsSugar   bool
-	IsW       bool
-	MsgPos    int
-	ArgsStart int
-	HasMsg    bool
+	IsSugar     bool
+	IsW         bool
+	MsgPos      int
+	ArgsStart   int
+	HasMsg      bool
+	Level       string // The level of the log entry, if fixed by the method.
+	HasLevelArg bool   // The first argument is the level of the log entry.
+	NoArgs      bool   // The arguments are not log arguments (fields or key-value pairs).
+	Derives     bool   // The method returns a new logger derived from the receiver.
+	IsWrapper   bool   // The function is a user-defined wrapper.
 }
 
 var zapFuncs = map[string]logFuncInfo{
-	"go.uber.org/zap.L":                        {},
-	"go.uber.org/zap.S":                        {},
-	"(*go.uber.org/zap.Logger).Debug":          {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.Logger).Info":           {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.Logger).Warn":           {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.Logger).Error":          {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.Logger).DPanic":         {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.Logger).Panic":          {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.Logger).Fatal":          {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.Logger).With":           {IsSugar: false, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.Logger).Sugar":          {IsSugar: false, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).Debug":   {IsSugar: true, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).Info":    {IsSugar: true, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).Warn":    {IsSugar: true, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).Error":   {IsSugar: true, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).DPanic":  {IsSugar: true, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).Panic":   {IsSugar: true, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).Fatal":   {IsSugar: true, ArgsStart: 0, HasMsg: false},
-	"(*go.uber.org/zap.SugaredLogger).Debugf":  {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Infof":   {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Warnf":   {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Errorf":  {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).DPanicf": {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Panicf":  {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Fatalf":  {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Debugw":  {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Infow":   {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Warnw":   {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Errorw":  {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).DPanicw": {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Panicw":  {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).Fatalw":  {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true},
-	"(*go.uber.org/zap.SugaredLogger).With":    {IsSugar: true, ArgsStart: 0, HasMsg: false},
+	"go.uber.org/zap.L":                            {},
+	"go.uber.org/zap.S":                            {},
+	"(*go.uber.org/zap.Logger).Debug":              {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelDebug},
+	"(*go.uber.org/zap.Logger).Info":               {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelInfo},
+	"(*go.uber.org/zap.Logger).Warn":               {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelWarn},
+	"(*go.uber.org/zap.Logger).Error":              {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelError},
+	"(*go.uber.org/zap.Logger).DPanic":             {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelDPanic},
+	"(*go.uber.org/zap.Logger).Panic":              {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelPanic},
+	"(*go.uber.org/zap.Logger).Fatal":              {IsSugar: false, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelFatal},
+	"(*go.uber.org/zap.Logger).Log":                {IsSugar: false, MsgPos: 1, ArgsStart: 2, HasMsg: true, HasLevelArg: true},
+	"(*go.uber.org/zap.Logger).Check":              {IsSugar: false, MsgPos: 1, ArgsStart: 2, HasMsg: true, HasLevelArg: true},
+	"(*go.uber.org/zap.Logger).With":               {IsSugar: false, ArgsStart: 0, HasMsg: false, Derives: true},
+	"(*go.uber.org/zap.Logger).WithLazy":           {IsSugar: false, ArgsStart: 0, HasMsg: false, Derives: true},
+	"(*go.uber.org/zap.Logger).Named":              {IsSugar: false, HasMsg: false, NoArgs: true, Derives: true},
+	"(*go.uber.org/zap.Logger).WithOptions":        {IsSugar: false, HasMsg: false, NoArgs: true, Derives: true},
+	"(*go.uber.org/zap.Logger).Sugar":              {IsSugar: false, ArgsStart: 0, HasMsg: false, Derives: true},
+	"(*go.uber.org/zap.SugaredLogger).Debug":       {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelDebug},
+	"(*go.uber.org/zap.SugaredLogger).Info":        {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelInfo},
+	"(*go.uber.org/zap.SugaredLogger).Warn":        {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelWarn},
+	"(*go.uber.org/zap.SugaredLogger).Error":       {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelError},
+	"(*go.uber.org/zap.SugaredLogger).DPanic":      {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelDPanic},
+	"(*go.uber.org/zap.SugaredLogger).Panic":       {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelPanic},
+	"(*go.uber.org/zap.SugaredLogger).Fatal":       {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelFatal},
+	"(*go.uber.org/zap.SugaredLogger).Log":         {IsSugar: true, ArgsStart: 1, HasMsg: false, HasLevelArg: true},
+	"(*go.uber.org/zap.SugaredLogger).Debugf":      {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelDebug},
+	"(*go.uber.org/zap.SugaredLogger).Infof":       {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelInfo},
+	"(*go.uber.org/zap.SugaredLogger).Warnf":       {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelWarn},
+	"(*go.uber.org/zap.SugaredLogger).Errorf":      {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelError},
+	"(*go.uber.org/zap.SugaredLogger).DPanicf":     {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelDPanic},
+	"(*go.uber.org/zap.SugaredLogger).Panicf":      {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelPanic},
+	"(*go.uber.org/zap.SugaredLogger).Fatalf":      {IsSugar: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelFatal},
+	"(*go.uber.org/zap.SugaredLogger).Logf":        {IsSugar: true, MsgPos: 1, ArgsStart: 2, HasMsg: true, HasLevelArg: true},
+	"(*go.uber.org/zap.SugaredLogger).Debugw":      {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelDebug},
+	"(*go.uber.org/zap.SugaredLogger).Infow":       {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelInfo},
+	"(*go.uber.org/zap.SugaredLogger).Warnw":       {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelWarn},
+	"(*go.uber.org/zap.SugaredLogger).Errorw":      {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelError},
+	"(*go.uber.org/zap.SugaredLogger).DPanicw":     {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelDPanic},
+	"(*go.uber.org/zap.SugaredLogger).Panicw":      {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelPanic},
+	"(*go.uber.org/zap.SugaredLogger).Fatalw":      {IsSugar: true, IsW: true, MsgPos: 0, ArgsStart: 1, HasMsg: true, Level: levelFatal},
+	"(*go.uber.org/zap.SugaredLogger).Logw":        {IsSugar: true, IsW: true, MsgPos: 1, ArgsStart: 2, HasMsg: true, HasLevelArg: true},
+	"(*go.uber.org/zap.SugaredLogger).Debugln":     {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelDebug},
+	"(*go.uber.org/zap.SugaredLogger).Infoln":      {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelInfo},
+	"(*go.uber.org/zap.SugaredLogger).Warnln":      {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelWarn},
+	"(*go.uber.org/zap.SugaredLogger).Errorln":     {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelError},
+	"(*go.uber.org/zap.SugaredLogger).DPanicln":    {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelDPanic},
+	"(*go.uber.org/zap.SugaredLogger).Panicln":     {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelPanic},
+	"(*go.uber.org/zap.SugaredLogger).Fatalln":     {IsSugar: true, ArgsStart: 0, HasMsg: false, Level: levelFatal},
+	"(*go.uber.org/zap.SugaredLogger).Logln":       {IsSugar: true, ArgsStart: 1, HasMsg: false, HasLevelArg: true},
+	"(*go.uber.org/zap.SugaredLogger).With":        {IsSugar: true, ArgsStart: 0, HasMsg: false, Derives: true},
+	"(*go.uber.org/zap.SugaredLogger).WithLazy":    {IsSugar: true, ArgsStart: 0, HasMsg: false, Derives: true},
+	"(*go.uber.org/zap.SugaredLogger).Named":       {IsSugar: true, HasMsg: false, NoArgs: true, Derives: true},
+	"(*go.uber.org/zap.SugaredLogger).WithOptions": {IsSugar: true, HasMsg: false, NoArgs: true, Derives: true},
+	"(*go.uber.org/zap.SugaredLogger).Desugar":     {IsSugar: false, HasMsg: false, NoArgs: true, Derives: true},
+}
+
+const (
+	wrapperStructured = "structured"
+	wrapperSugared    = "sugared"
+	wrapperKeyValue   = "key-value"
+)
+
+// logFuncs returns zapFuncs extended with the given wrappers, indexed by package path.
+func logFuncs(wrappers []Wrapper) map[string]map[funcRef]logFuncInfo {
+	funcs := make(map[string]map[funcRef]logFuncInfo)
+	add := func(fullName string, info logFuncInfo) {
+		pkgPath, ref := parseFuncName(fullName)
+		if funcs[pkgPath] == nil {
+			funcs[pkgPath] = make(map[funcRef]logFuncInfo)
+		}
+		funcs[pkgPath][ref] = info
+	}
+	for fullName, info := range zapFuncs {
+		add(fullName, info)
+	}
+	for _, w := range wrappers {
+		add(cleanVendorPath(w.Func), logFuncInfo{
+			IsSugar:   w.Style == wrapperSugared || w.Style == wrapperKeyValue,
+			IsW:       w.Style == wrapperKeyValue,
+			MsgPos:    w.MsgPos,
+			ArgsStart: w.ArgsStart,
+			HasMsg:    w.MsgPos < w.ArgsStart,
+			Level:     w.Level,
+			IsWrapper: true,
+		})
+	}
+	return funcs
 }
 
 func run(pass *analysis.Pass, opts *Options) {
-	inspector := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-
-	// First pass: collect all field constructor calls that are arguments to logger methods
-	processedFieldCalls := make(map[*ast.CallExpr]bool)
-	nodeFilter := []ast.Node{(*ast.CallExpr)(nil)}
-	inspector.Preorder(nodeFilter, func(node ast.Node) {
-		call := node.(*ast.CallExpr)
-		fn := typeutil.StaticCallee(pass.TypesInfo, call)
-		if fn == nil {
-			return
+	r := pass.ResultOf[opts.wrappers].(*wrappers).resolver
+	if !r.importsZap() {
+		return
+	}
+	inspector := r.inspector(pass)
+
+	paths := &keyPaths{pass: pass, r: r}
+	var sets keySets
+	programKeys := pass.ResultOf[opts.encoderKeys].(reservedKeys)
+	if opts.ForbidEncoderKeys {
+		sets.reserved = programKeys
+	}
+	if !opts.AllowRedundantFields {
+		sets.entry = programKeys.entryKeys()
+	}
+	var configs *configChecker
+	if opts.ConfigValidation {
+		configs = newConfigChecker(pass, opts, r)
+	}
+	loopLogging := slices.ContainsFunc(opts.LoopLoggingPackages, func(pattern string) bool { return matchPackage(pattern, cleanVendorPath(pass.Pkg.Path())) })
+	var (
+		stacks    []stackField    // Stack fields, checked against the construction of their loggers.
+		loopCalls []*ast.CallExpr // Logging calls inside loops, checked against the sampling of their loggers.
+	)
+	// The syntax checks run in a single traversal of the package.
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.GenDecl)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	if !opts.AllowGlobalVars {
+		nodeFilter = append(nodeFilter, (*ast.Ident)(nil))
+	}
+	inspector.WithStack(nodeFilter, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
 		}
-		cleanedFullName := cleanVendorPath(fn.FullName())
-		if _, ok := zapFuncs[cleanedFullName]; ok {
-			// This is a logger method - mark all field constructor arguments as processed
-			for _, arg := range call.Args {
-				if argCall, ok := arg.(*ast.CallExpr); ok {
-					if argFn := typeutil.StaticCallee(pass.TypesInfo, argCall); argFn != nil {
-						if argFn.Pkg() != nil && cleanVendorPath(argFn.Pkg().Path()) == "go.uber.org/zap" {
-							processedFieldCalls[argCall] = true
-						}
-					}
-				}
+		file := stack[0].(*ast.File)
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			visit(pass, node, stack, opts, r, paths, sets, &stacks)
+			checkConstructor(pass, opts, r, file, node)
+			if !opts.AllowNonTestLoggers {
+				checkTestLogger(pass, opts, r, file, node, stack)
+			}
+			if loopLogging && isLoopLogging(pass, r, node, stack) {
+				loopCalls = append(loopCalls, node)
+			}
+		case *ast.GenDecl, *ast.Ident:
+			if !opts.AllowGlobalVars {
+				checkGlobalVar(pass, r, node)
+			}
+		case *ast.ExprStmt, *ast.AssignStmt:
+			if !opts.AllowDiscardedLoggers {
+				checkDiscardedLogger(pass, r, node.(ast.Stmt))
+			}
+			if !opts.AllowIgnoredBuildErrors {
+				checkIgnoredBuildError(pass, r, node.(ast.Stmt))
 			}
+		case *ast.FuncDecl, *ast.FuncLit:
+			checkMarshalerFunc(pass, opts, r, node, sets.reserved)
 		}
+		if configs != nil {
+			configs.visit(file, node)
+		}
+		return true
 	})
 
-	// Second pass: visit all calls
-	inspector.Preorder(nodeFilter, func(node ast.Node) {
-		visit(pass, node.(*ast.CallExpr), opts, processedFieldCalls)
-	})
+	if configs != nil {
+		configs.finish()
+	}
+
+	if len(stacks) > 0 {
+		checkRedundantStacks(pass, r, stacks)
+	}
+
+	if len(loopCalls) > 0 {
+		checkLoopLogging(pass, r, loopCalls)
+	}
+
+	if !opts.AllowNilErrors {
+		checkNilErrors(pass, r)
+	}
+
+	if !opts.AllowMissingSync {
+		checkMissingSync(pass, opts, r)
+	}
+
+	if !opts.AllowCallerSkipMismatch {
+		checkCallerSkip(pass, opts, r)
+	}
+
+	if !opts.AllowInvalidCores {
+		checkCores(pass, r)
+	}
+}
+
+// zapModule is the path of the zap module.
+// Paths of the zap forks (Options.ZapPackages) are canonicalised to it, so that rules only compare against it.
+const zapModule = "go.uber.org/zap"
+
+// canonical removes vendor prefixes from a package path or a full object name,
+// and replaces the module paths of zap forks with the path of the zap module.
+func (opts *Options) canonical(name string) string {
+	name = cleanVendorPath(name)
+	for _, fork := range opts.ZapPackages {
+		for i := 0; i+len(fork) <= len(name); {
+			j := strings.Index(name[i:], fork)
+			if j == -1 {
+				break
+			}
+			start, end := i+j, i+j+len(fork)
+			if (start == 0 || strings.ContainsRune("/*[] ,\t()", rune(name[start-1]))) &&
+				(end == len(name) || strings.ContainsRune("./)", rune(name[end]))) {
+				name = name[:start] + zapModule + name[end:]
+				end = start + len(zapModule)
+			}
+			i = end
+		}
+	}
+	return name
+}
+
+// isZapPkg reports whether path is the path of a zap package, e.g. go.uber.org/zap or go.uber.org/zap/zapcore.
+func (opts *Options) isZapPkg(path string) bool {
+	path = opts.canonical(path)
+	return path == zapModule || strings.HasPrefix(path, zapModule+"/")
 }
 
 // cleanVendorPath removes vendor prefixes from package paths.
@@ -162,7 +382,7 @@ func cleanVendorPath(path string) string {
 		j := slashBefore - 1
 		for j >= 0 {
 			c := path[j]
-			if strings.ContainsRune("/*[] ,\t)", rune(c)) {
+			if strings.ContainsRune("/*[] ,\t()", rune(c)) {
 				break
 			}
 			j--
@@ -172,19 +392,45 @@ func cleanVendorPath(path string) string {
 	return path[:start] + path[i+len(vendor):]
 }
 
-func visit(pass *analysis.Pass, call *ast.CallExpr, opts *Options, processedFieldCalls map[*ast.CallExpr]bool) {
-	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+func visit(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node, opts *Options, r *resolver, paths *keyPaths, sets keySets, stacks *[]stackField) {
+	var parent ast.Node
+	if len(stack) > 1 {
+		parent = stack[len(stack)-2]
+	}
+	fn := r.callee(pass.TypesInfo, call)
 	if fn == nil {
 		return
 	}
-	originalFullName := fn.FullName()
-	cleanedFullName := cleanVendorPath(originalFullName)
+	var fullName string
+	if r.zapPkg(fn) != "" {
+		fullName = r.name(fn)
+	}
+
+	if checkGlobalSetter(pass, call, fullName, opts) {
+		return
+	}
+	if fullName == zapModule+".Any" || fullName == zapModule+".Reflect" {
+		if !opts.AllowAny {
+			checkAny(pass, r, call, fn.Name())
+		}
+		if opts.reflectionPolicy(pass.Pkg.Path()) == reflectionForbid {
+			checkReflectedField(pass, r, call, fn.Name())
+		}
+	}
+	if !opts.AllowStringMethods {
+		switch fullName {
+		case zapModule + ".String":
+			checkStringerField(pass, call)
+		case zapModule + ".Strings":
+			checkStringersField(pass, call, stack)
+		}
+	}
 
-	info, ok := zapFuncs[cleanedFullName]
+	info, ok := r.lookup(fn)
 	if !ok {
 		// Not a logger method - check if it's a standalone zap field constructor
 		// (e.g., zap.String("key", "value") not used as an argument to a logger method)
-		if !processedFieldCalls[call] && fn.Pkg() != nil && cleanVendorPath(fn.Pkg().Path()) == "go.uber.org/zap" {
+		if r.zapPkg(fn) == zapModule && !isLoggerArg(pass, r, call, parent) {
 			// Check if it's a field constructor function that takes a key as first argument
 			if len(call.Args) > 0 {
 				// Common zap field constructors all take a key as the first argument
@@ -206,12 +452,13 @@ func visit(pass *analysis.Pass, call *ast.CallExpr, opts *Options, processedFiel
 					}
 
 					if obj != nil && obj.Pkg() != nil {
-						pkgPath := cleanVendorPath(obj.Pkg().Path())
+						pkgPath := r.zapPkgs[obj.Pkg()]
 						// Check for both zapcore.Field and zap.Field (which is an alias)
-						if (pkgPath == "go.uber.org/zap/zapcore" || pkgPath == "go.uber.org/zap") && obj.Name() == "Field" {
+						if (pkgPath == zapModule+"/zapcore" || pkgPath == zapModule) && obj.Name() == "Field" {
 							// This is a standalone zap field constructor, check the key
-							checkAllKeys(pass, opts, func(yield func(ast.Expr) bool) {
-								yield(call.Args[0])
+							// and the keys nested in it, with paths relative to where the field is logged.
+							checkAllKeys(pass, opts, sets, func(yield func(logKey) bool) {
+								fieldKeys(pass, r, []ast.Expr{call}, rootPrefix, yield)
 							})
 						}
 					}
@@ -221,6 +468,10 @@ func visit(pass *analysis.Pass, call *ast.CallExpr, opts *Options, processedFiel
 		return
 	}
 
+	if !opts.AllowDerivesInLoops && info.Derives && r.zapPkg(fn) == zapModule {
+		checkDeriveInLoop(pass, call, fn, stack)
+	}
+
 	// Get the position for reporting - use selector position if available for better error location
 	reportPos := call.Pos()
 	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
@@ -228,19 +479,18 @@ func visit(pass *analysis.Pass, call *ast.CallExpr, opts *Options, processedFiel
 	}
 
 	if !opts.AllowGlobal {
-		if cleanedFullName == "go.uber.org/zap.L" || cleanedFullName == "go.uber.org/zap.S" {
+		if fullName == zapModule+".L" || fullName == zapModule+".S" {
 			pass.Reportf(reportPos, "global logger should not be used")
 			return
 		}
 	}
-	if !opts.AllowSugar && info.IsSugar {
+	if !opts.AllowSugar && info.IsSugar && !info.IsWrapper {
 		// For chained calls like sugar.With().Info(), only report on the inner call (.With)
 		// to avoid duplicate diagnostics. Skip if the receiver is a sugared logger call.
 		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
 			if innerCall, ok := sel.X.(*ast.CallExpr); ok {
-				if innerFn := typeutil.StaticCallee(pass.TypesInfo, innerCall); innerFn != nil {
-					innerFullName := cleanVendorPath(innerFn.FullName())
-					if innerInfo, ok := zapFuncs[innerFullName]; ok && innerInfo.IsSugar {
+				if innerFn := r.callee(pass.TypesInfo, innerCall); innerFn != nil {
+					if innerInfo, ok := r.lookup(innerFn); ok && innerInfo.IsSugar {
 						// This call's receiver is a sugared logger call, skip reporting
 						// (the inner call will be reported instead)
 						return
@@ -252,9 +502,21 @@ func visit(pass *analysis.Pass, call *ast.CallExpr, opts *Options, processedFiel
 		return
 	}
 
-	logArgs := call.Args[info.ArgsStart:]
+	if allowed := opts.allowedLevels(pass.Pkg.Path()); len(allowed) > 0 {
+		if level, ok := logLevel(pass.TypesInfo, call, info); ok && !slices.Contains(allowed, level) {
+			pass.Reportf(reportPos, "%q level should not be used", level)
+		}
+	}
 
-	if !opts.AllowDynamicMsg && info.HasMsg && len(call.Args) > info.MsgPos {
+	var logArgs []ast.Expr
+	if !info.NoArgs && len(call.Args) >= info.ArgsStart {
+		logArgs = call.Args[info.ArgsStart:]
+	}
+
+	// The message forwarded by a wrapper is checked where the wrapper is called.
+	forwarded := pass.ResultOf[opts.wrappers].(*wrappers).calls[call] != nil
+
+	if !opts.AllowDynamicMsg && !forwarded && info.HasMsg && len(call.Args) > info.MsgPos {
 		msgArg := call.Args[info.MsgPos]
 		if !isStaticMsg(pass.TypesInfo, msgArg) {
 			pass.Reportf(msgArg.Pos(), "message should be a string literal or a constant")
@@ -265,35 +527,65 @@ func visit(pass *analysis.Pass, call *ast.CallExpr, opts *Options, processedFiel
 		checkMsgStyle(pass, call.Args[info.MsgPos], opts.MsgStyle)
 	}
 
-	keys := allKeys(pass, fn.Name(), info, logArgs)
-	checkAllKeys(pass, opts, keys)
+	prefix := rootPrefix
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && (len(opts.ForbiddenKeys) > 0 || len(sets.entry) > 0 || len(sets.reserved) > 0) {
+		// Key paths are only matched by forbidden, reserved and entry keys.
+		if selection, ok := pass.TypesInfo.Selections[sel]; ok && selection.Kind() == types.MethodVal {
+			prefix = paths.prefix(sel.X)
+		}
+	}
+	keys := allKeys(pass, r, fn.Name(), info, logArgs, prefix)
+	checkAllKeys(pass, opts, sets, keys)
+
+	if !opts.AllowRedundantFields && info.HasMsg && fn.Name() != "Check" {
+		level, _ := logLevel(pass.TypesInfo, call, info)
+		checkRedundantFields(pass, r, info, call, level, logArgs, stacks)
+	}
+
+	if info.IsSugar && (info.IsW || fn.Name() == "With" || fn.Name() == "WithLazy") {
+		if opts.reflectionPolicy(pass.Pkg.Path()) == reflectionForbid {
+			checkReflectedValues(pass, r, logArgs)
+		}
+		if !opts.AllowStringMethods {
+			checkStringerValues(pass, r, logArgs)
+		}
+	}
+
+	if level, ok := logLevel(pass.TypesInfo, call, info); ok && level == levelDebug && !opts.AllowExpensiveDebugArgs {
+		checkExpensiveDebugArgs(pass, opts, r, info, logArgs, stack)
+	}
 
 	if !opts.AllowArgsOnSameLine && areArgsOnSameLine(pass.Fset, info.IsW, logArgs) {
 		pass.Reportf(call.Pos(), "arguments should be put on separate lines")
 	}
 }
 
-func allKeys(pass *analysis.Pass, funcName string, fnInfo logFuncInfo, args []ast.Expr) iter.Seq[ast.Expr] {
-	return func(yield func(key ast.Expr) bool) {
+// isLoggerArg reports whether call is an argument of parent, a call to a logging function or to zap.Dict.
+func isLoggerArg(pass *analysis.Pass, r *resolver, call *ast.CallExpr, parent ast.Node) bool {
+	parentCall, ok := parent.(*ast.CallExpr)
+	if !ok || !slices.Contains(parentCall.Args, ast.Expr(call)) {
+		return false
+	}
+	fn := r.callee(pass.TypesInfo, parentCall)
+	if fn == nil {
+		return false
+	}
+	if r.zapPkg(fn) == zapModule && fn.Name() == "Dict" {
+		return true
+	}
+	_, ok = r.lookup(fn)
+	return ok
+}
+
+// allKeys returns the keys of the log args, with their paths under the prefix of the logger.
+func allKeys(pass *analysis.Pass, r *resolver, funcName string, fnInfo logFuncInfo, args []ast.Expr, prefix keyPrefix) iter.Seq[logKey] {
+	return func(yield func(key logKey) bool) {
 		if !fnInfo.IsSugar {
-			for _, arg := range args {
-				if call, ok := arg.(*ast.CallExpr); ok {
-					if callee := typeutil.StaticCallee(pass.TypesInfo, call); callee != nil && callee.Pkg() != nil {
-						pkgPath := cleanVendorPath(callee.Pkg().Path())
-						if pkgPath == "go.uber.org/zap" && len(call.Args) > 0 {
-							if !yield(call.Args[0]) {
-								return
-							}
-						}
-					}
-				}
-			}
-		} else if fnInfo.IsW || funcName == "With" {
+			fieldKeys(pass, r, args, prefix, yield)
+		} else if fnInfo.IsW || funcName == "With" || funcName == "WithLazy" {
 			for i := 0; i < len(args); i += 2 {
-				if i < len(args) {
-					if !yield(args[i]) {
-						return
-					}
+				if !yield(prefix.key(pass.TypesInfo, args[i])) {
+					return
 				}
 			}
 		}
@@ -344,8 +636,39 @@ const (
 	pascalCase       = "pascal"
 	styleLowercased  = "lowercased"
 	styleCapitalized = "capitalized"
+	levelDebug       = "debug"
+	levelInfo        = "info"
+	levelWarn        = "warn"
+	levelError       = "error"
+	levelDPanic      = "dpanic"
+	levelPanic       = "panic"
+	levelFatal       = "fatal"
+	reflectionAllow  = "allow"
+	reflectionForbid = "forbid"
 )
 
+// zapLevels maps zapcore.Level values to their names.
+var zapLevels = map[int64]string{
+	-1: levelDebug,
+	0:  levelInfo,
+	1:  levelWarn,
+	2:  levelError,
+	3:  levelDPanic,
+	4:  levelPanic,
+	5:  levelFatal,
+}
+
+// zapLevelValues maps the names of the levels to their zapcore.Level values.
+var zapLevelValues = map[string]int64{
+	levelDebug:  -1,
+	levelInfo:   0,
+	levelWarn:   1,
+	levelError:  2,
+	levelDPanic: 3,
+	levelPanic:  4,
+	levelFatal:  5,
+}
+
 func validateOptions(opts *Options) error {
 	switch opts.MsgStyle {
 	case "", styleLowercased, styleCapitalized:
@@ -357,24 +680,197 @@ func validateOptions(opts *Options) error {
 	default:
 		return fmt.Errorf("zaplint: Options.KeyNamingCase=%s: %w", opts.KeyNamingCase, errInvalidValue)
 	}
+	if err := validateLevels("Options.AllowedLevels", opts.AllowedLevels); err != nil {
+		return err
+	}
+	for i, w := range opts.Wrappers {
+		switch w.Style {
+		case "", wrapperStructured, wrapperSugared, wrapperKeyValue:
+		default:
+			return fmt.Errorf("zaplint: Options.Wrappers[%d].Style=%s: %w", i, w.Style, errInvalidValue)
+		}
+		if w.MsgPos < 0 || w.ArgsStart < 0 {
+			return fmt.Errorf("zaplint: Options.Wrappers[%d]: negative position: %w", i, errInvalidValue)
+		}
+		if w.Level != "" {
+			if err := validateLevels(fmt.Sprintf("Options.Wrappers[%d].Level", i), []string{w.Level}); err != nil {
+				return err
+			}
+		}
+	}
+	for i, path := range opts.ZapPackages {
+		if path == "" || strings.HasSuffix(path, "/") {
+			return fmt.Errorf("zaplint: Options.ZapPackages[%d]=%s: %w", i, path, errInvalidValue)
+		}
+	}
+	switch opts.ReflectionPolicy {
+	case "", reflectionAllow, reflectionForbid:
+	default:
+		return fmt.Errorf("zaplint: Options.ReflectionPolicy=%s: %w", opts.ReflectionPolicy, errInvalidValue)
+	}
+	if err := opts.ConstructorPolicy.Production.validate("Options.ConstructorPolicy.Production"); err != nil {
+		return err
+	}
+	if err := opts.ConstructorPolicy.Test.validate("Options.ConstructorPolicy.Test"); err != nil {
+		return err
+	}
+	if err := opts.ConstructorPolicy.Main.validate("Options.ConstructorPolicy.Main"); err != nil {
+		return err
+	}
+	for i, o := range opts.Overrides {
+		if err := validateLevels(fmt.Sprintf("Options.Overrides[%d].AllowedLevels", i), o.AllowedLevels); err != nil {
+			return err
+		}
+		switch o.ReflectionPolicy {
+		case "", reflectionAllow, reflectionForbid:
+		default:
+			return fmt.Errorf("zaplint: Options.Overrides[%d].ReflectionPolicy=%s: %w", i, o.ReflectionPolicy, errInvalidValue)
+		}
+	}
+	return nil
+}
+
+func validateLevels(name string, levels []string) error {
+	known := slices.Collect(maps.Values(zapLevels))
+	for _, level := range levels {
+		if !slices.Contains(known, level) {
+			return fmt.Errorf("zaplint: %s=%s: %w", name, level, errInvalidValue)
+		}
+	}
 	return nil
 }
 
+// allowedLevels returns the levels allowed in the given package.
+func (opts *Options) allowedLevels(pkgPath string) []string {
+	levels := opts.AllowedLevels
+	for _, o := range opts.Overrides {
+		if o.AllowedLevels != nil && o.matches(pkgPath) {
+			levels = o.AllowedLevels
+		}
+	}
+	return levels
+}
+
+// matches reports whether the override applies to the given package.
+func (o *Override) matches(pkgPath string) bool {
+	pkgPath = cleanVendorPath(pkgPath)
+	for _, pattern := range o.Paths {
+		if matchPackage(pattern, pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPackage reports whether pkgPath matches pattern.
+// The pattern matches if it is equal to pkgPath or to a trailing sequence of its elements,
+// so that "internal/hotpath" matches "example.com/svc/internal/hotpath".
+// A trailing "/..." additionally matches all subpackages.
+func matchPackage(pattern, pkgPath string) bool {
+	base, recursive := strings.CutSuffix(pattern, "/...")
+	for p := pkgPath; ; {
+		if p == base || strings.HasSuffix(p, "/"+base) {
+			return true
+		}
+		i := strings.LastIndex(p, "/")
+		if !recursive || i == -1 {
+			return false
+		}
+		p = p[:i]
+	}
+}
+
+// logLevel returns the level of the log entry created by the call, if known statically.
+func logLevel(info *types.Info, call *ast.CallExpr, fnInfo logFuncInfo) (string, bool) {
+	if fnInfo.Level != "" {
+		return fnInfo.Level, true
+	}
+	if !fnInfo.HasLevelArg || len(call.Args) == 0 {
+		return "", false
+	}
+	tv, ok := info.Types[call.Args[0]]
+	if !ok || tv.Value == nil {
+		return "", false
+	}
+	v, exact := constant.Int64Val(tv.Value)
+	if !exact {
+		return "", false
+	}
+	level, ok := zapLevels[v]
+	return level, ok
+}
+
 func flags(opts *Options) *flag.FlagSet {
 	fset := flag.NewFlagSet("zaplint", flag.ContinueOnError)
 	fset.BoolVar(&opts.AllowGlobal, "allow-global", opts.AllowGlobal, "allow using global loggers (zap.L() and zap.S())")
+	fset.BoolVar(&opts.AllowGlobalVars, "allow-global-vars", opts.AllowGlobalVars, "allow storing loggers in package-level variables")
+	fset.BoolVar(&opts.AllowReplaceGlobals, "allow-replace-globals", opts.AllowReplaceGlobals, "allow calling zap.ReplaceGlobals outside of the main package")
+	fset.BoolVar(&opts.AllowRedirectStdLog, "allow-redirect-std-log", opts.AllowRedirectStdLog, "allow calling zap.RedirectStdLog(At) outside of the main package")
 	fset.BoolVar(&opts.AllowSugar, "allow-sugar", opts.AllowSugar, "allow using the sugared logger")
 	fset.BoolVar(&opts.AllowDynamicMsg, "allow-dynamic-msg", opts.AllowDynamicMsg, "allow dynamic log messages")
 	fset.StringVar(&opts.MsgStyle, "msg-style", opts.MsgStyle, "enforce message style (lowercased|capitalized)")
 	fset.BoolVar(&opts.AllowRawKeys, "allow-raw-keys", opts.AllowRawKeys, "allow using raw string keys")
 	fset.StringVar(&opts.KeyNamingCase, "key-naming-case", opts.KeyNamingCase, "enforce key naming convention (snake|kebab|camel|pascal)")
 	fset.BoolVar(&opts.AllowArgsOnSameLine, "allow-args-on-same-line", opts.AllowArgsOnSameLine, "allow putting arguments on the same line")
+	fset.BoolVar(&opts.AllowNilErrors, "allow-nil-errors", opts.AllowNilErrors, "allow logging errors that are provably nil")
+	fset.BoolVar(&opts.AllowMissingSync, "allow-missing-sync", opts.AllowMissingSync, "allow not syncing loggers created in the main package")
+	fset.BoolVar(&opts.AllowIgnoredBuildErrors, "allow-ignored-build-errors", opts.AllowIgnoredBuildErrors, "allow ignoring errors returned by logger constructors")
+	fset.BoolVar(&opts.AllowNonTestLoggers, "allow-non-test-loggers", opts.AllowNonTestLoggers, "allow creating loggers in tests without zaptest or observer")
+	fset.BoolVar(&opts.AllowCallerSkipMismatch, "allow-caller-skip-mismatch", opts.AllowCallerSkipMismatch, "allow wrappers using loggers whose zap.AddCallerSkip does not match the wrapper depth")
+	fset.BoolVar(&opts.AllowDuplicateKeys, "allow-duplicate-keys", opts.AllowDuplicateKeys, "allow adding the same key twice to an object in MarshalLogObject")
+	fset.BoolVar(&opts.AllowIgnoredEncoderErrors, "allow-ignored-encoder-errors", opts.AllowIgnoredEncoderErrors, "allow ignoring errors returned by encoders in marshalers")
+	fset.BoolVar(&opts.AllowAny, "allow-any", opts.AllowAny, "allow zap.Any and zap.Reflect for values with a specific constructor")
+	fset.StringVar(&opts.ReflectionPolicy, "reflection-policy", opts.ReflectionPolicy, "allow or forbid fields encoded with reflection (allow|forbid)")
+	fset.BoolVar(&opts.AllowExpensiveDebugArgs, "allow-expensive-debug-args", opts.AllowExpensiveDebugArgs, "allow calling expensive functions in the arguments of debug logging calls")
+	fset.BoolVar(&opts.AllowStringMethods, "allow-string-methods", opts.AllowStringMethods, "allow zap.String(key, x.String()) instead of zap.Stringer(key, x)")
+	fset.BoolVar(&opts.AllowDerivesInLoops, "allow-derives-in-loops", opts.AllowDerivesInLoops, "allow calling With, Named, Sugar, etc. inside loops on the same logger")
+	fset.BoolVar(&opts.AllowRedundantFields, "allow-redundant-fields", opts.AllowRedundantFields, "allow fields duplicating the time, caller, stack trace, etc. of the entry")
+	fset.BoolVar(&opts.ForbidEncoderKeys, "forbid-encoder-keys", opts.ForbidEncoderKeys, "forbid the keys set by the encoder configurations of the program as field keys")
+	fset.BoolVar(&opts.ConfigValidation, "config-validation", opts.ConfigValidation, "validate zap.Config, zap.SamplingConfig and zapcore.EncoderConfig values")
+	fset.BoolVar(&opts.AllowInvalidCores, "allow-invalid-cores", opts.AllowInvalidCores, "allow zapcore.Core implementations breaking the invariants of Check, With and Write")
+	fset.BoolVar(&opts.AllowDiscardedLoggers, "allow-discarded-loggers", opts.AllowDiscardedLoggers, "allow discarding loggers returned by With, Named, etc.")
 	fset.Func("forbidden-keys", "comma-separated list of forbidden keys", func(s string) error {
 		if s != "" {
 			opts.ForbiddenKeys = append(opts.ForbiddenKeys, strings.Split(s, ",")...)
 		}
 		return nil
 	})
+	fset.Func("expensive-funcs", "comma-separated list of functions whose calls are reported in the arguments of debug logging calls", func(s string) error {
+		if s != "" {
+			opts.ExpensiveFuncs = append(opts.ExpensiveFuncs, strings.Split(s, ",")...)
+		}
+		return nil
+	})
+	fset.Func("loop-logging-packages", "comma-separated list of packages where logging inside loops is reported", func(s string) error {
+		if s != "" {
+			opts.LoopLoggingPackages = append(opts.LoopLoggingPackages, strings.Split(s, ",")...)
+		}
+		return nil
+	})
+	fset.Func("sync-funcs", "comma-separated list of functions syncing the loggers passed to them", func(s string) error {
+		if s != "" {
+			opts.SyncFuncs = append(opts.SyncFuncs, strings.Split(s, ",")...)
+		}
+		return nil
+	})
+	fset.Func("logger-interfaces", "comma-separated list of interfaces whose methods matching zap methods are checked", func(s string) error {
+		if s != "" {
+			opts.LoggerInterfaces = append(opts.LoggerInterfaces, strings.Split(s, ",")...)
+		}
+		return nil
+	})
+	fset.Func("zap-packages", "comma-separated list of module paths of zap forks", func(s string) error {
+		if s != "" {
+			opts.ZapPackages = append(opts.ZapPackages, strings.Split(s, ",")...)
+		}
+		return nil
+	})
+	fset.Func("allowed-levels", "comma-separated list of allowed levels (debug|info|warn|error|dpanic|panic|fatal)", func(s string) error {
+		if s != "" {
+			opts.AllowedLevels = append(opts.AllowedLevels, strings.Split(s, ",")...)
+		}
+		return nil
+	})
 	return fset
 }
 
@@ -448,9 +944,16 @@ func checkMsgStyle(pass *analysis.Pass, msg ast.Expr, style string) {
 	}
 }
 
-func checkAllKeys(pass *analysis.Pass, opts *Options, keys iter.Seq[ast.Expr]) {
+// keySets are the keys checked in a package in addition to Options.ForbiddenKeys.
+type keySets struct {
+	entry    entryKeys    // Keys of the metadata of the entries, only matched at the top level.
+	reserved reservedKeys // Keys reserved by the encoder configurations of the program.
+}
+
+func checkAllKeys(pass *analysis.Pass, opts *Options, sets keySets, keys iter.Seq[logKey]) {
 	caseFn, caseName := getCaseConverter(opts.KeyNamingCase)
-	for keyExpr := range keys {
+	for key := range keys {
+		keyExpr := key.expr
 		// keyRender := render(pass.Fset, keyExpr)
 		if !opts.AllowRawKeys {
 			if _, ok := keyExpr.(*ast.BasicLit); ok {
@@ -458,12 +961,21 @@ func checkAllKeys(pass *analysis.Pass, opts *Options, keys iter.Seq[ast.Expr]) {
 			}
 		}
 		keyName, ok := getKeyName(keyExpr)
+		// Forbidden keys match either the key or its full path (e.g. "http.request.body").
+		if ok && slices.Contains(opts.ForbiddenKeys, keyName) {
+			pass.Reportf(keyExpr.Pos(), "%q key is forbidden and should not be used", keyName)
+		} else if key.path != "" && slices.Contains(opts.ForbiddenKeys, key.path) {
+			pass.Reportf(keyExpr.Pos(), "%q key is forbidden and should not be used", key.path)
+		} else if rk, found := sets.reserved[keyName]; ok && found {
+			pass.Reportf(keyExpr.Pos(), "%q key is reserved by EncoderConfig.%s (%s)", keyName, rk.Field, rk.Pos)
+		} else if rk, found := sets.reserved[key.path]; key.path != "" && found {
+			pass.Reportf(keyExpr.Pos(), "%q key is reserved by EncoderConfig.%s (%s)", key.path, rk.Field, rk.Pos)
+		} else if field, ok := sets.entry[key.path]; ok {
+			pass.Reportf(keyExpr.Pos(), "%q key duplicates the %s of the entry (EncoderConfig.%s)", key.path, entryKeyNames[field], field)
+		}
 		if !ok {
 			continue
 		}
-		if len(opts.ForbiddenKeys) > 0 && slices.Contains(opts.ForbiddenKeys, keyName) {
-			pass.Reportf(keyExpr.Pos(), "%q key is forbidden and should not be used", keyName)
-		}
 		if caseFn != nil && keyName != caseFn(keyName) {
 			pass.Report(analysis.Diagnostic{
 				Pos:     keyExpr.Pos(),
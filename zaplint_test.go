@@ -1,18 +1,35 @@
 package zaplint
 
 import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 func TestAnalyzer(t *testing.T) {
 	tests := map[string]struct {
 		opts Options
 		dir  string
+		fix  bool
 	}{
 		"no global":                   {opts: Options{AllowGlobal: false, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "no_global"},
 		"allow global":                {opts: Options{AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "allow_global"},
+		"no global vars":              {opts: Options{AllowGlobalVars: false, AllowReplaceGlobals: false, AllowRedirectStdLog: false, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "no_global_vars/..."},
+		"allow global vars":           {opts: Options{AllowGlobalVars: true, AllowReplaceGlobals: true, AllowRedirectStdLog: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "allow_global_vars"},
 		"no sugar":                    {opts: Options{AllowSugar: false, AllowGlobal: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "no_sugar"},
 		"allow sugar":                 {opts: Options{AllowSugar: true, AllowGlobal: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "allow_sugar"},
 		"static message":              {opts: Options{AllowDynamicMsg: false, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "no_dynamic_msg"},
@@ -25,13 +42,289 @@ func TestAnalyzer(t *testing.T) {
 		"forbidden keys":              {opts: Options{ForbiddenKeys: []string{"time", "level", "msg"}, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "forbidden_keys"},
 		"arguments on separate lines": {opts: Options{AllowArgsOnSameLine: false, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true}, dir: "no_args_on_sep_lines"},
 		"allow args on same line":     {opts: Options{AllowArgsOnSameLine: true, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true}, dir: "allow_args_on_same_line"},
+		"allowed levels": {opts: Options{
+			AllowedLevels: []string{"debug", "info", "error", "panic", "fatal"},
+			Overrides:     []Override{{Paths: []string{"allowed_levels/hotpath/..."}, AllowedLevels: []string{"info", "error", "panic", "fatal"}}},
+			AllowGlobal:   true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true,
+		}, dir: "allowed_levels/..."},
+		"no nil errors":           {opts: Options{AllowNilErrors: false, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "no_nil_errors"},
+		"no discarded loggers":    {opts: Options{AllowDiscardedLoggers: false, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "no_discarded_loggers", fix: true},
+		"allow discarded loggers": {opts: Options{AllowDiscardedLoggers: true, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "allow_discarded_loggers"},
+		"no missing sync":         {opts: Options{AllowMissingSync: false, AllowIgnoredBuildErrors: false, SyncFuncs: []string{"z/no_missing_sync.shutdown"}, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "no_missing_sync"},
+		"allow missing sync":      {opts: Options{AllowMissingSync: true, AllowIgnoredBuildErrors: true, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "allow_missing_sync"},
+		"constructor policy": {opts: Options{
+			ConstructorPolicy: ConstructorPolicy{
+				Production: ConstructorRules{Deny: []string{"zap.NewExample", "zap.NewDevelopment", "zap.NewDevelopmentConfig", "zap.NewNop"}},
+				Test:       ConstructorRules{Deny: []string{"zap.NewExample", "zap.NewDevelopment", "zap.NewNop", "zap.NewProduction"}},
+				Main:       ConstructorRules{Allow: []string{"zap.NewProduction", "zap.NewDevelopment"}},
+			},
+			AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true,
+		}, dir: "constructor_policy/...", fix: true},
+		"test loggers":           {opts: Options{AllowNonTestLoggers: false, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "test_loggers", fix: true},
+		"allow non-test loggers": {opts: Options{AllowNonTestLoggers: true, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "allow_non_test_loggers"},
+		"wrappers": {opts: Options{
+			Wrappers: []Wrapper{
+				{Func: "(*z/wrappers/log.Logger).Info", MsgPos: 0, ArgsStart: 1, Level: "info"},
+				{Func: "(*z/wrappers/log.Logger).Warnw", Style: "key-value", MsgPos: 0, ArgsStart: 1, Level: "warn"},
+				{Func: "z/wrappers/log.Debugf", Style: "sugared", MsgPos: 1, ArgsStart: 2, Level: "debug"},
+			},
+			AllowedLevels: []string{"debug", "info", "error"},
+			AllowGlobal:   true, AllowGlobalVars: true, AllowRawKeys: true, AllowArgsOnSameLine: true,
+		}, dir: "wrappers"},
+		"detected wrappers":          {opts: Options{AllowedLevels: []string{"info", "error"}, AllowGlobal: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "detected_wrappers"},
+		"caller skip":                {opts: Options{AllowGlobalVars: true, AllowSugar: true, AllowMissingSync: true}, dir: "caller_skip"},
+		"logger interfaces":          {opts: Options{AllowRawKeys: true, AllowedLevels: []string{"info", "error"}, LoggerInterfaces: []string{"z/logger_interfaces.ContextLogger"}}, dir: "logger_interfaces"},
+		"zap packages":               {opts: Options{AllowRawKeys: true, ZapPackages: []string{"z/zapfork"}}, dir: "zap_packages"},
+		"key paths":                  {opts: Options{AllowRawKeys: true, AllowSugar: true, AllowArgsOnSameLine: true, AllowMissingSync: true, ForbiddenKeys: []string{"password", "http.request.body"}}, dir: "key_paths"},
+		"no any":                     {opts: Options{AllowRawKeys: true}, dir: "no_any", fix: true},
+		"allow any":                  {opts: Options{AllowRawKeys: true, AllowAny: true}, dir: "allow_any"},
+		"expensive debug args":       {opts: Options{AllowRawKeys: true, AllowSugar: true, AllowArgsOnSameLine: true, AllowStringMethods: true, ExpensiveFuncs: []string{"z/expensive_debug_args.dump"}}, dir: "expensive_debug_args"},
+		"allow expensive debug args": {opts: Options{AllowRawKeys: true, AllowExpensiveDebugArgs: true}, dir: "allow_expensive_debug_args"},
+		"string methods":             {opts: Options{AllowRawKeys: true, AllowSugar: true, AllowArgsOnSameLine: true}, dir: "string_methods", fix: true},
+		"allow string methods":       {opts: Options{AllowRawKeys: true, AllowStringMethods: true}, dir: "allow_string_methods"},
+		"derives in loops":           {opts: Options{AllowRawKeys: true, AllowSugar: true, AllowArgsOnSameLine: true}, dir: "derives_in_loops"},
+		"allow derives in loops":     {opts: Options{AllowRawKeys: true, AllowDerivesInLoops: true}, dir: "allow_derives_in_loops"},
+		"cores":                      {opts: Options{}, dir: "cores"},
+		"allow invalid cores":        {opts: Options{AllowInvalidCores: true}, dir: "allow_invalid_cores"},
+		"marshalers":                 {opts: Options{AllowRawKeys: true, ForbiddenKeys: []string{"password", "admin.token"}}, dir: "marshalers"},
+		"allow marshaler errors":     {opts: Options{AllowRawKeys: true, AllowDuplicateKeys: true, AllowIgnoredEncoderErrors: true}, dir: "allow_marshaler_errors"},
+		"allow caller skip mismatch": {opts: Options{AllowGlobalVars: true, AllowSugar: true, AllowMissingSync: true, AllowCallerSkipMismatch: true}, dir: "allow_caller_skip_mismatch"},
+		"allow nil errors":           {opts: Options{AllowNilErrors: true, AllowGlobal: true, AllowSugar: true, AllowRawKeys: true, AllowArgsOnSameLine: true}, dir: "allow_nil_errors"},
+		"reflection policy": {opts: Options{
+			Overrides:    []Override{{Paths: []string{"reflection_policy/hotpath"}, ReflectionPolicy: "forbid"}},
+			AllowRawKeys: true, AllowSugar: true, AllowAny: true, AllowArgsOnSameLine: true,
+		}, dir: "reflection_policy/..."},
+		"redundant fields": {opts: Options{
+			AllowRawKeys: true, AllowSugar: true, AllowArgsOnSameLine: true, AllowIgnoredBuildErrors: true,
+		}, dir: "redundant_fields/..."},
+		"allow redundant fields": {opts: Options{
+			AllowRedundantFields: true, AllowRawKeys: true, AllowArgsOnSameLine: true,
+		}, dir: "allow_redundant_fields"},
+		"encoder keys": {opts: Options{
+			ForbidEncoderKeys: true, AllowRedundantFields: true, AllowRawKeys: true, AllowArgsOnSameLine: true,
+		}, dir: "encoder_keys/..."},
+		"config validation": {opts: Options{
+			ConfigValidation: true, AllowRawKeys: true, AllowArgsOnSameLine: true,
+		}, dir: "config_validation/..."},
+		"loop logging": {opts: Options{
+			LoopLoggingPackages: []string{"loop_logging/hotpath"},
+			AllowRawKeys:        true, AllowSugar: true, AllowArgsOnSameLine: true, AllowMissingSync: true, AllowIgnoredBuildErrors: true, AllowDerivesInLoops: true,
+		}, dir: "loop_logging/..."},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			analyzer := New(&tt.opts)
 			testdata := analysistest.TestData()
-			analysistest.Run(t, testdata, analyzer, "z/"+tt.dir)
+			if tt.fix {
+				analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "z/"+tt.dir)
+			} else {
+				analysistest.Run(t, testdata, analyzer, "z/"+tt.dir)
+			}
 		})
 	}
 }
+
+func TestValidateOptions(t *testing.T) {
+	tests := map[string]struct {
+		opts  Options
+		valid bool
+	}{
+		"known constructors":   {opts: Options{ConstructorPolicy: ConstructorPolicy{Production: ConstructorRules{Deny: []string{"zap.NewDevelopmentConfig"}}}}, valid: true},
+		"unknown allowed name": {opts: Options{ConstructorPolicy: ConstructorPolicy{Main: ConstructorRules{Allow: []string{"zap.NewProd"}}}}},
+		"unknown denied name":  {opts: Options{ConstructorPolicy: ConstructorPolicy{Test: ConstructorRules{Deny: []string{"NewNop"}}}}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateOptions(&tt.opts)
+			if tt.valid != (err == nil) || err != nil && !errors.Is(err, errInvalidValue) {
+				t.Errorf("validateOptions() = %v, want valid %t", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestKeyPathsValues(t *testing.T) {
+	const src = `package p
+
+func f(n int) {
+	defined := 1
+	reassigned := 2
+	reassigned = 3
+	incremented := 4
+	incremented++
+	ranged := 5
+	for ranged = range n {
+	}
+	addressed := 6
+	_ = &addressed
+	redeclared, x := 7, 8
+	redeclared, y := 9, 10
+	println(defined, reassigned, incremented, ranged, redeclared, x, y)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object), Uses: make(map[*ast.Ident]types.Object)}
+	if _, err := new(types.Config).Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatal(err)
+	}
+	paths := &keyPaths{pass: &analysis.Pass{Fset: fset, Files: []*ast.File{file}, TypesInfo: info}}
+	var got []string
+	for v := range paths.values() {
+		got = append(got, v.Name())
+	}
+	slices.Sort(got)
+	if want := []string{"defined", "x", "y"}; !slices.Equal(got, want) {
+		t.Errorf("values() = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkAnalyzer(b *testing.B) {
+	benchmarks := map[string]struct {
+		zap bool
+	}{
+		"zap":    {zap: true},
+		"no zap": {zap: false},
+	}
+
+	for name, bb := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			pkgs := loadCorpus(b, bb.zap)
+			analyzer := New(&Options{})
+			for b.Loop() {
+				graph, err := checker.Analyze([]*analysis.Analyzer{analyzer}, pkgs, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for act := range graph.All() {
+					if act.Err != nil {
+						b.Fatal(act.Err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCalleeLookup(b *testing.B) {
+	pkg := loadCorpus(b, true)[0]
+	opts := &Options{}
+	applyDefaults(opts)
+	opts.funcs = logFuncs(opts.Wrappers)
+	var calls []*ast.CallExpr
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if call, ok := node.(*ast.CallExpr); ok {
+				calls = append(calls, call)
+			}
+			return true
+		})
+	}
+	benchmarks := map[string]func() int{
+		// Formatting the full name of each callee and looking it up by package path and name.
+		"by name": func() int {
+			found := 0
+			for _, call := range calls {
+				fn := typeutil.StaticCallee(pkg.TypesInfo, call)
+				if fn == nil {
+					continue
+				}
+				path, ref := parseFuncName(cleanVendorPath(fn.FullName()))
+				if _, ok := opts.funcs[path][ref]; ok {
+					found++
+				}
+			}
+			return found
+		},
+		// Resolving the logging functions once, then looking up each callee by *types.Func.
+		"resolver": func() int {
+			r := newResolver(pkg.Types, opts)
+			found := 0
+			for _, call := range calls {
+				if fn := r.callee(pkg.TypesInfo, call); fn != nil {
+					if _, ok := r.lookup(fn); ok {
+						found++
+					}
+				}
+			}
+			return found
+		},
+	}
+
+	for name, lookup := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				if lookup() == 0 {
+					b.Fatal("no logging call found")
+				}
+			}
+		})
+	}
+}
+
+// loadCorpus generates a large synthetic package in the testdata module and loads it.
+func loadCorpus(b *testing.B, zap bool) []*packages.Package {
+	const files, funcs = 100, 20
+
+	module := filepath.Join(analysistest.TestData(), "src", "z")
+	dir, err := os.MkdirTemp(module, "bench")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { os.RemoveAll(dir) })
+
+	for i := range files {
+		var src strings.Builder
+		src.WriteString("package bench\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n")
+		if zap {
+			src.WriteString("\n\t\"go.uber.org/zap\"\n")
+		}
+		src.WriteString(")\n")
+		for j := range funcs {
+			if zap {
+				fmt.Fprintf(&src, `
+func handle%[1]d_%[2]d(logger *zap.Logger, id string, err error) string {
+	logger.Info("request handled",
+		zap.String("user_id", id),
+		zap.Int("attempt", %[2]d),
+	)
+	if err != nil {
+		logger.Error("request failed", zap.Error(err))
+	}
+	child := logger.With(zap.String("request_id", strings.ToUpper(id)))
+	child.Debug("request parsed", zap.Strings("parts", strings.Split(id, ".")))
+	return fmt.Sprintf("%%s-%%d", id, %[2]d)
+}
+`, i, j)
+			} else {
+				fmt.Fprintf(&src, `
+func handle%[1]d_%[2]d(id string, err error) string {
+	if err != nil {
+		fmt.Println("request failed", err)
+	}
+	parts := strings.Split(strings.ToUpper(id), ".")
+	return fmt.Sprintf("%%s-%%d", strings.Join(parts, "-"), %[2]d)
+}
+`, i, j)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d.go", i)), []byte(src.String()), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: module}
+	pkgs, err := packages.Load(cfg, "./"+filepath.Base(dir))
+	if err != nil {
+		b.Fatal(err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		b.Fatal("failed to load the corpus")
+	}
+	return pkgs
+}